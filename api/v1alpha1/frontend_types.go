@@ -24,6 +24,7 @@ import (
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -46,6 +47,86 @@ type ServiceMonitorConfig struct {
 	Disabled bool `json:"disabled,omitempty"`
 }
 
+// PodDisruptionBudgetConfig configures the PodDisruptionBudget of a Deployment. Without
+// minAvailable and maxUnavailable the budget allows one unavailable pod.
+// +kubebuilder:validation:XValidation:rule="!(has(self.minAvailable) && has(self.maxUnavailable))",message="minAvailable and maxUnavailable are mutually exclusive"
+type PodDisruptionBudgetConfig struct {
+	// Opts out of the environment default budget
+	Disabled bool `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+	// Number or percentage of pods that must stay available during a disruption
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty" yaml:"minAvailable,omitempty"`
+	// Number or percentage of pods that can be unavailable during a disruption
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty" yaml:"maxUnavailable,omitempty"`
+}
+
+// HeaderPolicy sets response headers on the requests matching a path glob
+type HeaderPolicy struct {
+	// Glob matched against the request path, e.g. *.js or /apps/landing/index.html.
+	// A glob without a leading / or * matches the file name in any directory.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[^\s{}"]+$`
+	Path string `json:"path" yaml:"path"`
+	// Headers set on the matching responses, an empty value removes the header
+	// +kubebuilder:validation:MinProperties=1
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k.matches('^[A-Za-z0-9-]+$'))",message="header names may only contain letters, digits and dashes"
+	Headers map[string]string `json:"headers" yaml:"headers"`
+}
+
+// CaddyConfig declares the routing of a Frontend. When the environment overwrites the Caddy
+// config the Frontend gets its own Caddyfile rendered from it.
+type CaddyConfig struct {
+	// Serves the index.html of the asset root for paths that match no file, for single page
+	// apps with client side routing
+	SPAFallback bool `json:"spaFallback,omitempty" yaml:"spaFallback,omitempty"`
+	// Compresses the responses with zstd and gzip
+	Compression bool `json:"compression,omitempty" yaml:"compression,omitempty"`
+	// Redirects answered instead of serving the assets
+	Redirects []CaddyRedirect `json:"redirects,omitempty" yaml:"redirects,omitempty"`
+	// Additional handle blocks, they take precedence over the asset routes
+	Handles []CaddyHandle `json:"handles,omitempty" yaml:"handles,omitempty"`
+}
+
+// CaddyRedirect redirects the requests of a path
+type CaddyRedirect struct {
+	// Request path, a trailing * matches every path with the prefix
+	// +kubebuilder:validation:Pattern=`^/[^\s{}"]*$`
+	From string `json:"from" yaml:"from"`
+	// Target of the redirect
+	// +kubebuilder:validation:Pattern=`^[^\s{}"]+$`
+	To string `json:"to" yaml:"to"`
+	// Answers with 301 instead of 302
+	Permanent bool `json:"permanent,omitempty" yaml:"permanent,omitempty"`
+}
+
+// CaddyHandle is a handle block of the Caddyfile
+type CaddyHandle struct {
+	// Path matcher of the block, a trailing * matches every path with the prefix
+	// +kubebuilder:validation:Pattern=`^/[^\s{}"]*$`
+	Path string `json:"path" yaml:"path"`
+	// Caddyfile directives of the block
+	// +kubebuilder:validation:MinLength=1
+	Directives string `json:"directives" yaml:"directives"`
+}
+
+// AutoscalingConfig configures a HorizontalPodAutoscaler for the frontend Deployment
+type AutoscalingConfig struct {
+	// Opts the frontend out of the environment default autoscaling
+	Disabled bool `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+	// Lower replica bound, defaults to the replicas of the frontend or the environment
+	// +kubebuilder:validation:Minimum=1
+	MinReplicas *int32 `json:"minReplicas,omitempty" yaml:"minReplicas,omitempty"`
+	// Upper replica bound, the autoscaler is only created when it is set
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas,omitempty" yaml:"maxReplicas,omitempty"`
+	// Average CPU utilization target in percent of the requested CPU, defaults to 80
+	// when no target is set
+	// +kubebuilder:validation:Minimum=1
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty" yaml:"targetCPUUtilizationPercentage,omitempty"`
+	// Average memory utilization target in percent of the requested memory
+	// +kubebuilder:validation:Minimum=1
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty" yaml:"targetMemoryUtilizationPercentage,omitempty"`
+}
+
 type SearchEntry struct {
 	ID          string       `json:"id" yaml:"id"`
 	Href        string       `json:"href" yaml:"href"`
@@ -103,10 +184,16 @@ type FrontendSpec struct {
 	BundleSegments     []*BundleSegment     `json:"bundleSegments,omitempty" yaml:"bundleSegments,omitempty"`
 	NavigationSegments []*NavigationSegment `json:"navigationSegments,omitempty" yaml:"navigationSegments,omitempty"`
 	AssetsPrefix       string               `json:"assetsPrefix,omitempty" yaml:"assetsPrefix,omitempty"`
+	// Directories of the image the push cache publishes, replaces /srv/dist published under
+	// the Frontend name
+	// +kubebuilder:validation:MaxItems=10
+	AssetSources []AssetSource `json:"assetSources,omitempty" yaml:"assetSources,omitempty"`
 	// Akamai cache bust opt-out
 	AkamaiCacheBustDisable bool `json:"akamaiCacheBustDisable,omitempty" yaml:"akamaiCacheBustDisable,omitempty"`
 	// Files to cache bust
 	AkamaiCacheBustPaths []string `json:"akamaiCacheBustPaths,omitempty" yaml:"akamaiCacheBustPaths,omitempty"`
+	// Akamai cache tags to purge along with the files
+	AkamaiCacheBustTags []string `json:"akamaiCacheBustTags,omitempty" yaml:"akamaiCacheBustTags,omitempty"`
 	// The search index partials for the resource
 	SearchEntries []*SearchEntry `json:"searchEntries,omitempty" yaml:"searchEntries,omitempty"`
 	// Data for the all services dropdown
@@ -115,19 +202,225 @@ type FrontendSpec struct {
 	WidgetRegistry    []*WidgetModuleFederationMetadata `json:"widgetRegistry,omitempty" yaml:"widgetRegistry,omitempty"`
 	BaseWidgetLayouts []*BaseWidgetDashboardTemplate    `json:"baseWidgetLayouts,omitempty" yaml:"baseWidgetLayouts,omitempty"`
 	Replicas          *int32                            `json:"replicas,omitempty" yaml:"replicas,omitempty"`
+	// Scales the Deployment with a HorizontalPodAutoscaler, replaces the environment default
+	Autoscaling *AutoscalingConfig `json:"autoscaling,omitempty" yaml:"autoscaling,omitempty"`
+	// Protects the Deployment against voluntary disruptions when it runs more than one
+	// replica, replaces the environment default
+	PodDisruptionBudget *PodDisruptionBudgetConfig `json:"podDisruptionBudget,omitempty" yaml:"podDisruptionBudget,omitempty"`
+	// Routing of the Caddyfile rendered for the Frontend when the environment sets
+	// overwriteCaddyConfig
+	Caddy *CaddyConfig `json:"caddy,omitempty" yaml:"caddy,omitempty"`
+	// Response headers per path glob, defaults for the headers that neither the environment
+	// nor the application set
+	HeaderPolicies []HeaderPolicy `json:"headerPolicies,omitempty" yaml:"headerPolicies,omitempty"`
+	// Hostnames the frontend is served on when the environment routingMode is Route,
+	// replaces the environment hostname
+	Hosts []string `json:"hosts,omitempty" yaml:"hosts,omitempty"`
+	// Reruns the push cache Job and the cache bust of the Frontend
+	Jobs *FrontendJobsConfig `json:"jobs,omitempty" yaml:"jobs,omitempty"`
+	// Rolls a new image out through a canary instead of replacing the pods of the Deployment
+	Rollout *RolloutConfig `json:"rollout,omitempty" yaml:"rollout,omitempty"`
 	// Injects configuration from application when enabled
 	FeoConfigEnabled bool `json:"feoConfigEnabled,omitempty" yaml:"feoConfigEnabled,omitempty"`
 }
 
+// Channels the assets of a Frontend are served on
+const (
+	AssetChannelStable  = "stable"
+	AssetChannelPreview = "preview"
+)
+
+// AssetSource is a directory of the Frontend image the push cache publishes to the object store
+type AssetSource struct {
+	// Channel the assets are served on, stable or preview. Defaults to stable.
+	// +kubebuilder:validation:Enum=stable;preview
+	Channel string `json:"channel,omitempty" yaml:"channel,omitempty"`
+	// Directory of the assets in the image, defaults to the asset root of the channel
+	// +kubebuilder:validation:Pattern=`^(/[a-zA-Z0-9._-]+)+$`
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Prefix the assets are published under in the object store, defaults to the frontend
+	// name for stable and preview/<name> for preview, e.g. preview/landing
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._-]+(/[a-zA-Z0-9._-]+)*$`
+	Destination string `json:"destination,omitempty" yaml:"destination,omitempty"`
+}
+
+// FrontendJobsConfig reruns the jobs of a Frontend without changing its image
+type FrontendJobsConfig struct {
+	// Changing the token recreates the push cache Job and purges the CDN cache again for the
+	// current image, e.g. set it to the current time
+	RerunToken string `json:"rerunToken,omitempty" yaml:"rerunToken,omitempty"`
+}
+
+// Strategies rolling a new image of a Frontend out
+const (
+	RolloutStrategyRollingUpdate = "RollingUpdate"
+	RolloutStrategyCanary        = "Canary"
+)
+
+// RolloutConfig selects how a new image of a Frontend is rolled out. A canary serves the new
+// image from a second Deployment to a share of the requests, and replaces the image of the
+// Deployment once it stayed ready for the soak duration and the push cache published its
+// assets. A canary failing its probes is rolled back.
+type RolloutConfig struct {
+	// RollingUpdate or Canary, defaults to RollingUpdate
+	// +kubebuilder:validation:Enum=RollingUpdate;Canary
+	Strategy string `json:"strategy,omitempty" yaml:"strategy,omitempty"`
+	// Percentage of the requests served by the canary, defaults to 10
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	CanaryWeight *int32 `json:"canaryWeight,omitempty" yaml:"canaryWeight,omitempty"`
+	// Replicas of the canary Deployment, defaults to 1
+	// +kubebuilder:validation:Minimum=1
+	CanaryReplicas *int32 `json:"canaryReplicas,omitempty" yaml:"canaryReplicas,omitempty"`
+	// Time the ready canary serves requests before it is promoted, defaults to 5m
+	SoakDuration *metav1.Duration `json:"soakDuration,omitempty" yaml:"soakDuration,omitempty"`
+	// Time the canary has to become ready in before it is rolled back, defaults to 10m
+	ProgressDeadline *metav1.Duration `json:"progressDeadline,omitempty" yaml:"progressDeadline,omitempty"`
+}
+
 var ReconciliationSuccessful = "ReconciliationSuccessful"
 var ReconciliationFailed = "ReconciliationFailed"
 var FrontendsReady = "FrontendsReady"
+var ConfigurationWarnings = "ConfigurationWarnings"
+var NavigationReferencesResolved = "NavigationReferencesResolved"
+var AssetsPublished = "AssetsPublished"
+var ImageRolledOut = "ImageRolledOut"
 
 // FrontendStatus defines the observed state of Frontend
 type FrontendStatus struct {
 	Deployments FrontendDeployments `json:"deployments,omitempty"`
 	Ready       bool                `json:"ready"`
 	Conditions  []metav1.Condition  `json:"conditions,omitempty"`
+	// Routes created for the Frontend when the environment routingMode is Route
+	Routes []FrontendRouteStatus `json:"routes,omitempty"`
+	// Akamai cache bust of the current Frontend image
+	CacheBust *CacheBustStatus `json:"cacheBust,omitempty"`
+	// Push cache runs publishing the Frontend assets to the object store
+	PushCache *PushCacheStatus `json:"pushCache,omitempty"`
+	// Digest the Frontend image resolved to when the environment enables imageResolution
+	Image *ImageStatus `json:"image,omitempty"`
+	// Canary rollout of the Frontend image when its rollout strategy is Canary
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+}
+
+// ImageStatus reports the digest the image tag of a Frontend resolved to
+type ImageStatus struct {
+	// Image of the Frontend spec
+	Image string `json:"image"`
+	// Digest the image resolved to
+	Digest string `json:"digest,omitempty"`
+	// Image pinned to the digest, run by the Deployment and the push cache Job
+	ResolvedImage string `json:"resolvedImage,omitempty"`
+	// Time the image was last resolved
+	ResolveTime *metav1.Time `json:"resolveTime,omitempty"`
+	// Reason the last resolution failed, the last resolved digest stays pinned
+	Message string `json:"message,omitempty"`
+}
+
+// Phases of a canary rollout
+const (
+	RolloutProgressing = "Progressing"
+	RolloutSoaking     = "Soaking"
+	RolloutPromoted    = "Promoted"
+	RolloutRolledBack  = "RolledBack"
+)
+
+// RolloutStatus reports the canary rollout of a Frontend image
+type RolloutStatus struct {
+	// Image served by the Deployment of the Frontend
+	StableImage string `json:"stableImage"`
+	// Image of the current or last canary
+	CanaryImage string `json:"canaryImage,omitempty"`
+	// Progressing until the canary is ready, Soaking while it serves requests, then Promoted
+	// or RolledBack
+	Phase string `json:"phase"`
+	// Time the canary was created
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Time the canary became ready, the soak duration starts
+	ReadyTime *metav1.Time `json:"readyTime,omitempty"`
+	// Time the canary was promoted or rolled back
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// What the rollout waits for, or the reason the canary was rolled back
+	Message string `json:"message,omitempty"`
+}
+
+// Phases of a push cache run
+const (
+	PushCacheRunning   = "Running"
+	PushCacheSucceeded = "Succeeded"
+	PushCacheFailed    = "Failed"
+)
+
+// PushCacheRun reports a run of the push cache Job of a Frontend
+type PushCacheRun struct {
+	// Job of the run
+	JobName string `json:"jobName"`
+	// UID of the Job, a recreated Job is a new run
+	JobUID string `json:"jobUID,omitempty"`
+	// Frontend image the assets are published from
+	Image string `json:"image,omitempty"`
+	// Valpop image publishing the assets
+	ValpopImage string `json:"valpopImage,omitempty"`
+	// Rerun token of the Frontend the Job was created for
+	RerunToken string `json:"rerunToken,omitempty"`
+	// Running, Succeeded or Failed
+	Phase string `json:"phase"`
+	// Time the Job started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Time the Job succeeded or failed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Reason the run failed, with the termination reason of the failed pod
+	Message string `json:"message,omitempty"`
+}
+
+// PushCacheStatus reports the current and previous push cache runs of a Frontend
+type PushCacheStatus struct {
+	// Current or most recent run
+	LastRun *PushCacheRun `json:"lastRun,omitempty"`
+	// Previous runs, most recent first. The last 10 are kept.
+	History []PushCacheRun `json:"history,omitempty"`
+}
+
+// Phases of the Akamai cache bust
+const (
+	CacheBustPending   = "Pending"
+	CacheBustRunning   = "Running"
+	CacheBustSucceeded = "Succeeded"
+	CacheBustFailed    = "Failed"
+)
+
+// CacheBustStatus reports the outcome of the Akamai cache bust of a Frontend image
+type CacheBustStatus struct {
+	// Frontend image the cache bust runs for
+	Image string `json:"image,omitempty"`
+	// Rerun token of the Frontend the cache bust runs for
+	RerunToken string `json:"rerunToken,omitempty"`
+	// Pending until the image is rolled out, then Running, Succeeded or Failed
+	Phase string `json:"phase"`
+	// Attempts made so far, failed attempts are retried with exponential backoff
+	Attempts int32 `json:"attempts,omitempty"`
+	// URLs purged
+	URLs []string `json:"urls,omitempty"`
+	// Cache tags purged
+	Tags []string `json:"tags,omitempty"`
+	// Fast Purge IDs of the accepted purge requests
+	PurgeIDs []string `json:"purgeIDs,omitempty"`
+	// Time of the last purge attempt
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+	// Time the purge completed
+	PurgeTime *metav1.Time `json:"purgeTime,omitempty"`
+	// Reason the last attempt failed
+	Message string `json:"message,omitempty"`
+}
+
+// FrontendRouteStatus reports the admission of a Route by the OpenShift router
+type FrontendRouteStatus struct {
+	Name string `json:"name"`
+	// Host the Route was admitted with
+	Host     string `json:"host,omitempty"`
+	Admitted bool   `json:"admitted"`
+	// Reason the router rejected the Route
+	Message string `json:"message,omitempty"`
 }
 
 type FrontendDeployments struct {
@@ -0,0 +1,391 @@
+/*
+Copyright 2026 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var frontendlog = logf.Log.WithName("frontend-resource")
+
+var headerNameRegexp = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// FrontendValidator validates Frontend resources before they are admitted by the API server.
+// The Client is used to resolve the FrontendEnvironment referenced by spec.envName; when it
+// is nil only the self-contained spec checks are performed.
+type FrontendValidator struct {
+	Client client.Reader
+}
+
+// SetupFrontendWebhookWithManager registers the Frontend validating webhook with the manager.
+func SetupFrontendWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &Frontend{}).
+		WithValidator(&FrontendValidator{Client: mgr.GetAPIReader()}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-cloud-redhat-com-v1alpha1-frontend,mutating=false,failurePolicy=fail,sideEffects=None,groups=cloud.redhat.com,resources=frontends,verbs=create;update,versions=v1alpha1,name=vfrontend.kb.io,admissionReviewVersions={v1}
+
+// ValidateCreate implements admission.Validator so a webhook will be registered for the type
+func (v *FrontendValidator) ValidateCreate(ctx context.Context, fe *Frontend) (admission.Warnings, error) {
+	frontendlog.Info("validate create", "name", fe.Name, "namespace", fe.Namespace)
+	return v.validate(ctx, fe)
+}
+
+// ValidateUpdate implements admission.Validator so a webhook will be registered for the type
+func (v *FrontendValidator) ValidateUpdate(ctx context.Context, _, fe *Frontend) (admission.Warnings, error) {
+	frontendlog.Info("validate update", "name", fe.Name, "namespace", fe.Namespace)
+
+	// Never block finalizer removal on an object that is already going away
+	if fe.GetDeletionTimestamp() != nil {
+		return nil, nil
+	}
+	return v.validate(ctx, fe)
+}
+
+// ValidateDelete implements admission.Validator so a webhook will be registered for the type
+func (v *FrontendValidator) ValidateDelete(_ context.Context, fe *Frontend) (admission.Warnings, error) {
+	frontendlog.Info("validate delete", "name", fe.Name, "namespace", fe.Namespace)
+	return nil, nil
+}
+
+func (v *FrontendValidator) validate(ctx context.Context, fe *Frontend) (admission.Warnings, error) {
+	allErrs := ValidateFrontend(fe)
+	warnings := admission.Warnings{}
+
+	if v.Client != nil && fe.Spec.EnvName != "" {
+		env := &FrontendEnvironment{}
+		err := v.Client.Get(ctx, types.NamespacedName{Name: fe.Spec.EnvName}, env)
+		switch {
+		case apierrors.IsNotFound(err):
+			allErrs = append(allErrs, field.NotFound(field.NewPath("spec", "envName"), fe.Spec.EnvName))
+		case err != nil:
+			return warnings, apierrors.NewInternalError(fmt.Errorf("could not get FrontendEnvironment %s: %w", fe.Spec.EnvName, err))
+		default:
+			warnings = append(warnings, frontendEnvironmentWarnings(fe, env)...)
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		schema.GroupKind{Group: GroupVersion.Group, Kind: "Frontend"},
+		fe.Name, allErrs,
+	)
+}
+
+type frontendValidationFunc func(*Frontend) field.ErrorList
+
+// ValidateFrontend runs all the checks that do not need access to the cluster and
+// returns every problem found, each with the path of the offending field.
+func ValidateFrontend(fe *Frontend) field.ErrorList {
+	vfns := []frontendValidationFunc{
+		validateEnvName,
+		validateModule,
+		validateSearchEntries,
+		validateServiceTiles,
+		validateBundleSegments,
+		validateNavigationSegments,
+		validateHeaderPolicies,
+		validateCaddy,
+	}
+
+	allErrs := field.ErrorList{}
+	for _, validation := range vfns {
+		allErrs = append(allErrs, validation(fe)...)
+	}
+	return allErrs
+}
+
+func validateEnvName(fe *Frontend) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if fe.Spec.EnvName == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec", "envName"), "frontend must reference a FrontendEnvironment"))
+	}
+	return allErrs
+}
+
+func validateModule(fe *Frontend) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if fe.Spec.Module == nil {
+		return allErrs
+	}
+
+	modulesPath := field.NewPath("spec", "module", "modules")
+	for modIdx, module := range fe.Spec.Module.Modules {
+		for routeIdx, route := range module.Routes {
+			if route.Pathname == "" {
+				allErrs = append(allErrs, field.Required(modulesPath.Index(modIdx).Child("routes").Index(routeIdx).Child("pathname"), "route must have a pathname"))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateSearchEntries(fe *Frontend) field.ErrorList {
+	allErrs := field.ErrorList{}
+	entriesPath := field.NewPath("spec", "searchEntries")
+	seen := map[string]bool{}
+
+	for idx, entry := range fe.Spec.SearchEntries {
+		if entry == nil {
+			allErrs = append(allErrs, field.Required(entriesPath.Index(idx), "search entry must not be null"))
+			continue
+		}
+		if entry.ID == "" {
+			allErrs = append(allErrs, field.Required(entriesPath.Index(idx).Child("id"), "search entry must have an id"))
+			continue
+		}
+		if seen[entry.ID] {
+			allErrs = append(allErrs, field.Duplicate(entriesPath.Index(idx).Child("id"), entry.ID))
+		}
+		seen[entry.ID] = true
+	}
+	return allErrs
+}
+
+func validateServiceTiles(fe *Frontend) field.ErrorList {
+	allErrs := field.ErrorList{}
+	tilesPath := field.NewPath("spec", "serviceTiles")
+
+	for idx, tile := range fe.Spec.ServiceTiles {
+		if tile == nil {
+			allErrs = append(allErrs, field.Required(tilesPath.Index(idx), "service tile must not be null"))
+			continue
+		}
+		if tile.ID == "" {
+			allErrs = append(allErrs, field.Required(tilesPath.Index(idx).Child("id"), "service tile must have an id"))
+		}
+		if tile.Section == "" {
+			allErrs = append(allErrs, field.Required(tilesPath.Index(idx).Child("section"), "service tile must reference a service category"))
+		}
+		if tile.Group == "" {
+			allErrs = append(allErrs, field.Required(tilesPath.Index(idx).Child("group"), "service tile must reference a service category group"))
+		}
+	}
+	return allErrs
+}
+
+func validateBundleSegments(fe *Frontend) field.ErrorList {
+	allErrs := field.ErrorList{}
+	segmentsPath := field.NewPath("spec", "bundleSegments")
+	seen := map[string]bool{}
+
+	for idx, segment := range fe.Spec.BundleSegments {
+		segmentPath := segmentsPath.Index(idx)
+		if segment == nil {
+			allErrs = append(allErrs, field.Required(segmentPath, "bundle segment must not be null"))
+			continue
+		}
+		if segment.SegmentID == "" {
+			allErrs = append(allErrs, field.Required(segmentPath.Child("segmentId"), "bundle segment must have a segmentId"))
+		}
+		if segment.BundleID == "" {
+			allErrs = append(allErrs, field.Required(segmentPath.Child("bundleId"), "bundle segment must reference a bundle"))
+		}
+		if segment.NavItems == nil {
+			allErrs = append(allErrs, field.Required(segmentPath.Child("navItems"), "bundle segment must define navItems"))
+		} else {
+			allErrs = append(allErrs, validateChromeNavItems(*segment.NavItems, segmentPath.Child("navItems"))...)
+		}
+
+		key := segment.BundleID + "/" + segment.SegmentID
+		if segment.SegmentID != "" && seen[key] {
+			allErrs = append(allErrs, field.Duplicate(segmentPath.Child("segmentId"), segment.SegmentID))
+		}
+		seen[key] = true
+	}
+	return allErrs
+}
+
+func validateNavigationSegments(fe *Frontend) field.ErrorList {
+	allErrs := field.ErrorList{}
+	segmentsPath := field.NewPath("spec", "navigationSegments")
+	seen := map[string]bool{}
+
+	for idx, segment := range fe.Spec.NavigationSegments {
+		segmentPath := segmentsPath.Index(idx)
+		if segment == nil {
+			allErrs = append(allErrs, field.Required(segmentPath, "navigation segment must not be null"))
+			continue
+		}
+		if segment.SegmentID == "" {
+			allErrs = append(allErrs, field.Required(segmentPath.Child("segmentId"), "navigation segment must have a segmentId"))
+		} else if seen[segment.SegmentID] {
+			allErrs = append(allErrs, field.Duplicate(segmentPath.Child("segmentId"), segment.SegmentID))
+		}
+		seen[segment.SegmentID] = true
+
+		if segment.NavItems == nil {
+			allErrs = append(allErrs, field.Required(segmentPath.Child("navItems"), "navigation segment must define navItems"))
+		} else {
+			allErrs = append(allErrs, validateChromeNavItems(*segment.NavItems, segmentPath.Child("navItems"))...)
+		}
+	}
+	return allErrs
+}
+
+// validateHeaderPolicies makes sure every policy renders as a single Caddyfile directive
+func validateHeaderPolicies(fe *Frontend) field.ErrorList {
+	allErrs := field.ErrorList{}
+	policiesPath := field.NewPath("spec", "headerPolicies")
+	seen := map[string]bool{}
+
+	for idx, policy := range fe.Spec.HeaderPolicies {
+		policyPath := policiesPath.Index(idx)
+		switch {
+		case policy.Path == "":
+			allErrs = append(allErrs, field.Required(policyPath.Child("path"), "header policy must have a path glob"))
+		case strings.ContainsAny(policy.Path, " \t\r\n{}\""):
+			allErrs = append(allErrs, field.Invalid(policyPath.Child("path"), policy.Path, "path glob must not contain whitespace, braces or quotes"))
+		case seen[policy.Path]:
+			allErrs = append(allErrs, field.Duplicate(policyPath.Child("path"), policy.Path))
+		}
+		seen[policy.Path] = true
+
+		if len(policy.Headers) == 0 {
+			allErrs = append(allErrs, field.Required(policyPath.Child("headers"), "header policy must set at least one header"))
+		}
+		for name, value := range policy.Headers {
+			if !headerNameRegexp.MatchString(name) {
+				allErrs = append(allErrs, field.Invalid(policyPath.Child("headers").Key(name), name, "header names may only contain letters, digits and dashes"))
+			}
+			if strings.ContainsAny(value, "\r\n") {
+				allErrs = append(allErrs, field.Invalid(policyPath.Child("headers").Key(name), value, "header value must be a single line"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateCaddy makes sure the routing renders into a Caddyfile that parses, the directives
+// of a handle block must not close the block early
+func validateCaddy(fe *Frontend) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if fe.Spec.Caddy == nil {
+		return allErrs
+	}
+	caddyPath := field.NewPath("spec", "caddy")
+
+	for idx, redirect := range fe.Spec.Caddy.Redirects {
+		redirectPath := caddyPath.Child("redirects").Index(idx)
+		if !strings.HasPrefix(redirect.From, "/") || strings.ContainsAny(redirect.From, " \t\r\n{}\"") {
+			allErrs = append(allErrs, field.Invalid(redirectPath.Child("from"), redirect.From, "redirect must match an absolute path without whitespace, braces or quotes"))
+		}
+		if redirect.To == "" || strings.ContainsAny(redirect.To, " \t\r\n{}\"") {
+			allErrs = append(allErrs, field.Invalid(redirectPath.Child("to"), redirect.To, "redirect target must not be empty or contain whitespace, braces or quotes"))
+		}
+	}
+
+	for idx, handle := range fe.Spec.Caddy.Handles {
+		handlePath := caddyPath.Child("handles").Index(idx)
+		if !strings.HasPrefix(handle.Path, "/") || strings.ContainsAny(handle.Path, " \t\r\n{}\"") {
+			allErrs = append(allErrs, field.Invalid(handlePath.Child("path"), handle.Path, "handle must match an absolute path without whitespace, braces or quotes"))
+		}
+		if strings.TrimSpace(handle.Directives) == "" {
+			allErrs = append(allErrs, field.Required(handlePath.Child("directives"), "handle must have directives"))
+		} else if !BalancedBraces(handle.Directives) {
+			allErrs = append(allErrs, field.Invalid(handlePath.Child("directives"), handle.Directives, "directives must have balanced braces"))
+		}
+	}
+	return allErrs
+}
+
+// BalancedBraces returns true when every block opened in the directives is closed again
+func BalancedBraces(directives string) bool {
+	depth := 0
+	for _, c := range directives {
+		switch c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
+// validateChromeNavItems walks a nav item tree and makes sure every segment reference can be resolved by name
+func validateChromeNavItems(navItems []ChromeNavItem, navPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for idx, navItem := range navItems {
+		itemPath := navPath.Index(idx)
+		if navItem.HasSegmentRef() {
+			if navItem.SegmentRef.FrontendName == "" {
+				allErrs = append(allErrs, field.Required(itemPath.Child("segmentRef", "frontendName"), "segment reference must name a frontend"))
+			}
+			if navItem.SegmentRef.SegmentID == "" {
+				allErrs = append(allErrs, field.Required(itemPath.Child("segmentRef", "segmentId"), "segment reference must name a segment"))
+			}
+		}
+		allErrs = append(allErrs, validateChromeNavItems(navItem.NavItems, itemPath.Child("navItems"))...)
+		allErrs = append(allErrs, validateChromeNavItems(navItem.Routes, itemPath.Child("routes"))...)
+	}
+	return allErrs
+}
+
+// frontendEnvironmentWarnings reports references that are valid on their own but do not match
+// anything in the environment yet. They are warnings rather than errors because the environment
+// and its frontends are often rolled out in separate merge requests.
+func frontendEnvironmentWarnings(fe *Frontend, env *FrontendEnvironment) admission.Warnings {
+	warnings := admission.Warnings{}
+
+	if env.Spec.Bundles != nil {
+		bundleIDs := map[string]bool{}
+		for _, bundle := range *env.Spec.Bundles {
+			bundleIDs[bundle.ID] = true
+		}
+		for idx, segment := range fe.Spec.BundleSegments {
+			if segment != nil && segment.BundleID != "" && !bundleIDs[segment.BundleID] {
+				warnings = append(warnings, fmt.Sprintf("spec.bundleSegments[%d].bundleId: bundle %q is not defined in FrontendEnvironment %s", idx, segment.BundleID, env.Name))
+			}
+		}
+	}
+
+	if env.Spec.ServiceCategories != nil {
+		groups := map[string]bool{}
+		for _, category := range *env.Spec.ServiceCategories {
+			for _, group := range category.Groups {
+				groups[category.ID+"/"+group.ID] = true
+			}
+		}
+		for idx, tile := range fe.Spec.ServiceTiles {
+			if tile != nil && tile.Section != "" && tile.Group != "" && !groups[tile.Section+"/"+tile.Group] {
+				warnings = append(warnings, fmt.Sprintf("spec.serviceTiles[%d]: service category %q group %q is not defined in FrontendEnvironment %s", idx, tile.Section, tile.Group, env.Name))
+			}
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,244 @@
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newValidFrontend() *Frontend {
+	return &Frontend{
+		ObjectMeta: metav1.ObjectMeta{Name: "inventory", Namespace: "boot"},
+		Spec: FrontendSpec{
+			EnvName: "test-env",
+			Module: &FedModule{
+				ManifestLocation: "/apps/inventory/fed-mods.json",
+				Modules: []Module{{
+					ID:     "inventory",
+					Module: "./RootApp",
+					Routes: []Route{{Pathname: "/insights/inventory"}},
+				}},
+			},
+			SearchEntries: []*SearchEntry{{ID: "inventory", Title: "Inventory", Href: "/insights/inventory"}},
+			ServiceTiles:  []*ServiceTile{{ID: "inventory", Section: "insights", Group: "rhel"}},
+			BundleSegments: []*BundleSegment{{
+				SegmentID: "inventory-segment",
+				BundleID:  "insights",
+				NavItems:  &[]ChromeNavItem{{Title: "Inventory", Href: "/insights/inventory"}},
+			}},
+			NavigationSegments: []*NavigationSegment{{
+				SegmentID: "inventory-nav",
+				NavItems:  &[]ChromeNavItem{{SegmentRef: &SegmentRef{FrontendName: "chrome", SegmentID: "shared"}}},
+			}},
+		},
+	}
+}
+
+func newTestEnvironment() *FrontendEnvironment {
+	return &FrontendEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-env"},
+		Spec: FrontendEnvironmentSpec{
+			SSO:     "https://sso.example.com",
+			Bundles: &[]FrontendBundles{{ID: "insights", Title: "Insights"}},
+			ServiceCategories: &[]FrontendServiceCategory{{
+				ID:     "insights",
+				Title:  "Insights",
+				Groups: []FrontendServiceCategoryGroup{{ID: "rhel", Title: "RHEL"}},
+			}},
+		},
+	}
+}
+
+func newTestValidator(objs ...runtime.Object) *FrontendValidator {
+	s := runtime.NewScheme()
+	if err := AddToScheme(s); err != nil {
+		panic(err)
+	}
+	return &FrontendValidator{Client: fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build()}
+}
+
+func errorFields(t *testing.T, err error) []string {
+	t.Helper()
+	statusErr, ok := err.(*apierrors.StatusError)
+	if !ok {
+		t.Fatalf("expected a StatusError, got %T: %v", err, err)
+	}
+	fields := []string{}
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		fields = append(fields, cause.Field)
+	}
+	return fields
+}
+
+func TestValidateFrontendAcceptsValidSpec(t *testing.T) {
+	if errs := ValidateFrontend(newValidFrontend()); len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+}
+
+func TestValidateFrontendReportsFieldPaths(t *testing.T) {
+	fe := newValidFrontend()
+	fe.Spec.EnvName = ""
+	fe.Spec.Module.Modules[0].Routes = append(fe.Spec.Module.Modules[0].Routes, Route{})
+	fe.Spec.SearchEntries = append(fe.Spec.SearchEntries, &SearchEntry{Title: "No ID"}, &SearchEntry{ID: "inventory"}, nil)
+	fe.Spec.BundleSegments = append(fe.Spec.BundleSegments, &BundleSegment{SegmentID: "nil-items", BundleID: "insights"})
+	fe.Spec.NavigationSegments[0].NavItems = &[]ChromeNavItem{{
+		Title:    "Group",
+		GroupID:  "group",
+		NavItems: []ChromeNavItem{{SegmentRef: &SegmentRef{FrontendName: "chrome"}}},
+	}}
+
+	errs := ValidateFrontend(fe)
+
+	expected := []string{
+		"spec.envName",
+		"spec.module.modules[0].routes[1].pathname",
+		"spec.searchEntries[1].id",
+		"spec.searchEntries[2].id",
+		"spec.searchEntries[3]",
+		"spec.bundleSegments[1].navItems",
+		"spec.navigationSegments[0].navItems[0].navItems[0].segmentRef.segmentId",
+	}
+	got := map[string]bool{}
+	for _, err := range errs {
+		got[err.Field] = true
+	}
+	for _, field := range expected {
+		if !got[field] {
+			t.Errorf("expected an error for %s, got %v", field, errs)
+		}
+	}
+	if len(errs) != len(expected) {
+		t.Errorf("expected %d errors, got %d: %v", len(expected), len(errs), errs)
+	}
+}
+
+func TestValidateFrontendHeaderPolicies(t *testing.T) {
+	fe := newValidFrontend()
+	fe.Spec.HeaderPolicies = []HeaderPolicy{
+		{Path: "*.js", Headers: map[string]string{"Cache-Control": "public, max-age=31536000, immutable"}},
+		{Path: "fed-mods.json", Headers: map[string]string{"Cache-Control": "no-cache"}},
+	}
+	if errs := ValidateFrontend(fe); len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+
+	fe.Spec.HeaderPolicies = append(fe.Spec.HeaderPolicies,
+		HeaderPolicy{Path: "*.js", Headers: map[string]string{"Vary": "Accept"}},
+		HeaderPolicy{Path: "/apps/{name}", Headers: map[string]string{"X Frame": "DENY"}},
+		HeaderPolicy{Path: "*.html", Headers: map[string]string{"Content-Security-Policy": "default-src 'self'\nheader X-Injected 1"}},
+		HeaderPolicy{Path: "*.css"},
+	)
+
+	errs := ValidateFrontend(fe)
+
+	expected := []string{
+		"spec.headerPolicies[2].path",
+		"spec.headerPolicies[3].path",
+		"spec.headerPolicies[3].headers[X Frame]",
+		"spec.headerPolicies[4].headers[Content-Security-Policy]",
+		"spec.headerPolicies[5].headers",
+	}
+	got := map[string]bool{}
+	for _, err := range errs {
+		got[err.Field] = true
+	}
+	for _, field := range expected {
+		if !got[field] {
+			t.Errorf("expected an error for %s, got %v", field, errs)
+		}
+	}
+	if len(errs) != len(expected) {
+		t.Errorf("expected %d errors, got %d: %v", len(expected), len(errs), errs)
+	}
+}
+
+func TestValidateFrontendCaddy(t *testing.T) {
+	fe := newValidFrontend()
+	fe.Spec.Caddy = &CaddyConfig{
+		SPAFallback: true,
+		Redirects:   []CaddyRedirect{{From: "/apps/inventory/old*", To: "/apps/inventory/", Permanent: true}},
+		Handles:     []CaddyHandle{{Path: "/apps/inventory/api*", Directives: "reverse_proxy inventory-api:8000 {\n    header_up Host {upstream_hostport}\n}"}},
+	}
+	if errs := ValidateFrontend(fe); len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+
+	fe.Spec.Caddy.Redirects = append(fe.Spec.Caddy.Redirects, CaddyRedirect{From: "apps/inventory", To: "/apps/inventory/ permanent"})
+	fe.Spec.Caddy.Handles = append(fe.Spec.Caddy.Handles,
+		CaddyHandle{Path: "/apps/inventory/static*", Directives: "respond 404\n}\n:8080 {\nrespond 200"},
+		CaddyHandle{Path: "/apps/inventory/empty*", Directives: " "},
+	)
+
+	errs := ValidateFrontend(fe)
+
+	expected := []string{
+		"spec.caddy.redirects[1].from",
+		"spec.caddy.redirects[1].to",
+		"spec.caddy.handles[1].directives",
+		"spec.caddy.handles[2].directives",
+	}
+	got := map[string]bool{}
+	for _, err := range errs {
+		got[err.Field] = true
+	}
+	for _, field := range expected {
+		if !got[field] {
+			t.Errorf("expected an error for %s, got %v", field, errs)
+		}
+	}
+	if len(errs) != len(expected) {
+		t.Errorf("expected %d errors, got %d: %v", len(expected), len(errs), errs)
+	}
+}
+
+func TestFrontendValidatorRejectsUnknownEnvironment(t *testing.T) {
+	v := newTestValidator()
+
+	_, err := v.ValidateCreate(context.Background(), newValidFrontend())
+	if err == nil {
+		t.Fatal("expected an error for a missing FrontendEnvironment")
+	}
+	fields := errorFields(t, err)
+	if len(fields) != 1 || fields[0] != "spec.envName" {
+		t.Errorf("expected a single spec.envName error, got %v", fields)
+	}
+}
+
+func TestFrontendValidatorWarnsOnUnknownReferences(t *testing.T) {
+	v := newTestValidator(newTestEnvironment())
+	fe := newValidFrontend()
+	fe.Spec.BundleSegments[0].BundleID = "missing-bundle"
+	fe.Spec.ServiceTiles[0].Group = "missing-group"
+
+	warnings, err := v.ValidateCreate(context.Background(), fe)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(warnings) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0], "spec.bundleSegments[0].bundleId") {
+		t.Errorf("unexpected bundle warning %q", warnings[0])
+	}
+	if !strings.Contains(warnings[1], "spec.serviceTiles[0]") {
+		t.Errorf("unexpected service tile warning %q", warnings[1])
+	}
+}
+
+func TestFrontendValidatorSkipsObjectsBeingDeleted(t *testing.T) {
+	v := newTestValidator()
+	fe := newValidFrontend()
+	fe.Spec.EnvName = ""
+	now := metav1.Now()
+	fe.DeletionTimestamp = &now
+
+	if _, err := v.ValidateUpdate(context.Background(), fe, fe); err != nil {
+		t.Fatalf("expected deletion updates to be admitted, got %v", err)
+	}
+}
@@ -70,7 +70,104 @@ type FrontendServiceCategoryGenerated struct {
 	Groups []FrontendServiceCategoryGroupGenerated `json:"groups" yaml:"groups"`
 }
 
+// Routing modes of a FrontendEnvironment
+const (
+	RoutingModeIngress    = "Ingress"
+	RoutingModeGatewayAPI = "GatewayAPI"
+	RoutingModeRoute      = "Route"
+)
+
+// GatewayParentRef references the Gateway that HTTPRoutes attach to
+type GatewayParentRef struct {
+	Name string `json:"name"`
+	// Namespace of the Gateway, defaults to the namespace of the route
+	Namespace string `json:"namespace,omitempty"`
+	// Listener of the Gateway, the routes attach to all listeners when empty
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// RouteTLSConfig configures the TLS of the OpenShift Routes of an environment
+// +kubebuilder:validation:XValidation:rule="!has(self.termination) || self.termination != 'passthrough' || !has(self.insecureEdgeTerminationPolicy) || self.insecureEdgeTerminationPolicy != 'Allow'",message="passthrough routes can not allow insecure traffic"
+type RouteTLSConfig struct {
+	// Where TLS is terminated, defaults to reencrypt with ssl and edge without
+	// +kubebuilder:validation:Enum={"edge", "passthrough", "reencrypt"}
+	Termination string `json:"termination,omitempty"`
+	// Handling of plain HTTP requests, defaults to Redirect
+	// +kubebuilder:validation:Enum={"None", "Allow", "Redirect"}
+	InsecureEdgeTerminationPolicy string `json:"insecureEdgeTerminationPolicy,omitempty"`
+}
+
+// CDN providers the cache can be purged from
+const (
+	CachePurgeAkamai     = "Akamai"
+	CachePurgeFastly     = "Fastly"
+	CachePurgeCloudFront = "CloudFront"
+	CachePurgeHTTP       = "HTTP"
+)
+
+// CachePurgeConfig selects the CDN provider the frontend assets are purged from. The
+// purged URLs are built from the AkamaiCacheBustURLs whatever the provider.
+type CachePurgeConfig struct {
+	// Provider of the CDN, defaults to Akamai
+	// +kubebuilder:validation:Enum={"Akamai", "Fastly", "CloudFront", "HTTP"}
+	Provider string `json:"provider,omitempty"`
+	// Secret with the credentials of the provider, Akamai defaults to the AkamaiSecretName.
+	// Fastly reads the api_token key, CloudFront the aws_access_key_id, aws_secret_access_key
+	// and optional aws_session_token keys and HTTP an optional bearer token key.
+	SecretName string `json:"secretName,omitempty"`
+	// Fastly service the cache tags are purged from
+	FastlyServiceID string `json:"fastlyServiceID,omitempty"`
+	// CloudFront distribution the paths of the URLs are invalidated in
+	CloudFrontDistributionID string `json:"cloudFrontDistributionID,omitempty"`
+	// Method the HTTP provider requests the URLs with, defaults to PURGE
+	HTTPMethod string `json:"httpMethod,omitempty"`
+	// Header the HTTP provider sends the cache tags in, e.g. xkey for Varnish. Cache tags are
+	// not purged without it.
+	HTTPTagHeader string `json:"httpTagHeader,omitempty"`
+}
+
+// ObjectStoreConfig configures the object store the push cache jobs publish the frontend
+// assets to and the reverse proxy serves them from. Unset fields fall back to the operator's
+// PUSHCACHE_AWS_* environment variables.
+type ObjectStoreConfig struct {
+	// Bucket the assets are published to, defaults to PUSHCACHE_AWS_BUCKET_NAME
+	Bucket string `json:"bucket,omitempty"`
+	// Hostname of the object store, defaults to PUSHCACHE_AWS_ENDPOINT
+	Endpoint string `json:"endpoint,omitempty"`
+	// Region of the bucket, defaults to PUSHCACHE_AWS_REGION
+	Region string `json:"region,omitempty"`
+	// Port of the object store, defaults to PUSHCACHE_AWS_PORT or 443
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port *int32 `json:"port,omitempty"`
+	// Whether the reverse proxy reaches the object store over TLS, defaults to true on port 443
+	TLS *bool `json:"tls,omitempty"`
+	// Prefix of the keys of the assets in the bucket, environments sharing a bucket are kept
+	// apart by it
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._-]+(/[a-zA-Z0-9._-]+)*$`
+	PathPrefix string `json:"pathPrefix,omitempty"`
+	// Secret with the aws_access_key_id and aws_secret_access_key of the object store. The
+	// operator copies them into a Secret in every namespace using them, the operator's
+	// PUSHCACHE_AWS_ACCESS_KEY_ID and PUSHCACHE_AWS_SECRET_ACCESS_KEY are used without it.
+	CredentialsSecretRef *v1.SecretReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// ImageResolutionConfig resolves the image tags of the frontends to the digests they point
+// to, a tag pushed again rolls the Deployment out and reruns the push cache
+type ImageResolutionConfig struct {
+	// Pins the resolved digests in the Deployments and push cache jobs
+	Enabled bool `json:"enabled,omitempty"`
+	// Interval the tags are resolved again at to follow tags that move, e.g. 10m. The tags are
+	// only resolved when the image changes without it.
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+	// Secret of type kubernetes.io/dockerconfigjson in the Frontend namespace with the
+	// credentials of the registries
+	PullSecretName string `json:"pullSecretName,omitempty"`
+}
+
 // FrontendEnvironmentSpec defines the desired state of FrontendEnvironment
+// +kubebuilder:validation:XValidation:rule="!has(self.routingMode) || self.routingMode != 'GatewayAPI' || has(self.gateway)",message="gateway is required when routingMode is GatewayAPI"
+// +kubebuilder:validation:XValidation:rule="!has(self.routingMode) || self.routingMode != 'GatewayAPI' || !has(self.whitelist) || size(self.whitelist) == 0",message="whitelist is not supported with routingMode GatewayAPI, restrict source ranges on the Gateway"
 type FrontendEnvironmentSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
@@ -88,6 +185,15 @@ type FrontendEnvironmentSpec struct {
 	// These annotations will be applied to the ingress objects created by the frontend
 	IngressAnnotations map[string]string `json:"ingressAnnotations,omitempty"`
 
+	// RoutingMode selects how frontends and the reverse proxy are exposed, Ingress objects
+	// (default), Gateway API HTTPRoutes attached to the gateway or OpenShift Routes
+	// +kubebuilder:validation:Enum={"Ingress", "GatewayAPI", "Route"}
+	RoutingMode string `json:"routingMode,omitempty"`
+	// Gateway the HTTPRoutes attach to when routingMode is GatewayAPI
+	Gateway *GatewayParentRef `json:"gateway,omitempty"`
+	// TLS of the Routes when routingMode is Route
+	RouteTLS *RouteTLSConfig `json:"routeTLS,omitempty"`
+
 	// Hostname
 	Hostname string `json:"hostname,omitempty"`
 
@@ -109,8 +215,15 @@ type FrontendEnvironmentSpec struct {
 	GenerateNavJSON bool `json:"generateNavJSON,omitempty"`
 	// Enable Akamai Cache Bust
 	EnableAkamaiCacheBust bool `json:"enableAkamaiCacheBust,omitempty"`
-	// Set Akamai Cache Bust Image
+	// Deprecated: the operator purges the cache itself, the image is no longer run
 	AkamaiCacheBustImage string `json:"akamaiCacheBustImage,omitempty"`
+	// Fast Purge action, delete removes the content from the cache while invalidate only
+	// marks it stale. Defaults to delete.
+	// +kubebuilder:validation:Enum={"delete", "invalidate"}
+	AkamaiCacheBustAction string `json:"akamaiCacheBustAction,omitempty"`
+	// Akamai network the content is purged from. Defaults to production.
+	// +kubebuilder:validation:Enum={"production", "staging"}
+	AkamaiCacheBustNetwork string `json:"akamaiCacheBustNetwork,omitempty"`
 	// Deprecated: Users should move to AkamaiCacheBustURLs
 	// Preserving for backwards compatibility
 	AkamaiCacheBustURL string `json:"akamaiCacheBustURL,omitempty"`
@@ -118,19 +231,27 @@ type FrontendEnvironmentSpec struct {
 	AkamaiCacheBustURLs []string `json:"akamaiCacheBustURLs,omitempty"`
 	// The name of the secret we will use to get the akamai credentials
 	AkamaiSecretName string `json:"akamaiSecretName,omitempty"`
+	// CDN the cache is purged from when EnableAkamaiCacheBust is set, defaults to Akamai
+	CachePurge *CachePurgeConfig `json:"cachePurge,omitempty"`
 	// List of namespaces that should receive a copy of the frontend configuration as a config map
 	// By configurations we mean the fed-modules.json, navigation files, etc.
 	TargetNamespaces []string `json:"targetNamespaces,omitempty" yaml:"targetNamespaces,omitempty"`
 	// For the ChromeUI to render additional global components
 	ServiceCategories *[]FrontendServiceCategory `json:"serviceCategories,omitempty" yaml:"serviceCategories,omitempty"`
 	// Custom HTTP Headers
-	// These populate an ENV var that is then added into the caddy config as a header block
+	// These are enforced on every response through the CADDY_HEADER_POLICY header block and the
+	// deprecated CADDY_HTTP_HEADERS env var
 	HTTPHeaders map[string]string `json:"httpHeaders,omitempty"`
+	// Response headers per path glob for every frontend, enforced like the httpHeaders.
+	// Frontend headerPolicies can not override them.
+	HeaderPolicies []HeaderPolicy `json:"headerPolicies,omitempty" yaml:"headerPolicies,omitempty"`
 	// OverwriteCaddyConfig determines if the operator should overwrite
 	// frontend container Caddyfiles with a common core Caddyfile
 	OverwriteCaddyConfig bool `json:"overwriteCaddyConfig,omitempty"`
 	// Enable Push Cache Container
 	EnablePushCache bool `json:"enablePushCache,omitempty"`
+	// Object store of the push cache and the reverse proxy
+	ObjectStore *ObjectStoreConfig `json:"objectStore,omitempty"`
 	// Valpop Image for Push Cache Jobs
 	// If not set, falls back to using the frontend container image
 	ValpopImage string `json:"valpopImage,omitempty"`
@@ -146,8 +267,15 @@ type FrontendEnvironmentSpec struct {
 	// Any jobs with creation timestamp before this value will be deleted and recreated
 	// Example: "2026-01-13T10:30:00Z"
 	DeployCutoffTimestampPushCache string `json:"deployCutoffTimestampPushCache,omitempty"`
+	// Resolves the image tags of the frontends to digests and pins them in the Deployments and
+	// push cache jobs
+	ImageResolution *ImageResolutionConfig `json:"imageResolution,omitempty"`
 
 	DefaultReplicas *int32 `json:"defaultReplicas,omitempty" yaml:"defaultReplicas,omitempty"`
+	// Autoscaling for the frontends that do not configure their own
+	DefaultAutoscaling *AutoscalingConfig `json:"defaultAutoscaling,omitempty" yaml:"defaultAutoscaling,omitempty"`
+	// PodDisruptionBudget for the frontends that do not configure their own and the reverse proxy
+	DefaultPodDisruptionBudget *PodDisruptionBudgetConfig `json:"defaultPodDisruptionBudget,omitempty" yaml:"defaultPodDisruptionBudget,omitempty"`
 	// For the ChromeUI to render navigation bundles
 	Bundles *[]FrontendBundles `json:"bundles,omitempty" yaml:"bundles,omitempty"`
 
@@ -161,14 +289,37 @@ type MonitoringConfig struct {
 	Disabled bool   `json:"disabled"`
 }
 
+var ConfigGenerated = "ConfigGenerated"
+var ReverseProxyReady = "ReverseProxyReady"
+var RoutingModeApplied = "RoutingModeApplied"
+
+// GeneratedConfigMap identifies a ConfigMap the operator generated for the environment
+type GeneratedConfigMap struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Hash of the ConfigMap data, changes whenever the generated config changes
+	Hash string `json:"hash"`
+}
+
 // FrontendEnvironmentStatus defines the observed state of FrontendEnvironment
 type FrontendEnvironmentStatus struct {
+	// Number of Frontends that reference this environment
+	Frontends int32 `json:"frontends"`
+	// Number of Frontends whose managed deployments are all ready
+	ReadyFrontends int32 `json:"readyFrontends"`
+	// Frontends (namespace/name) that inject configuration with feoConfigEnabled
+	FeoConfigEnabledFrontends []string `json:"feoConfigEnabledFrontends,omitempty"`
+	// ConfigMaps generated for the environment, including the TargetNamespaces copies
+	ConfigMaps []GeneratedConfigMap `json:"configMaps,omitempty"`
+	Conditions []metav1.Condition   `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,shortName=feenv
-// +kubebuilder:printcolumn:name="Namespace",type="string",JSONPath=".status.targetNamespace"
+// +kubebuilder:printcolumn:name="Frontends",type="integer",JSONPath=".status.frontends"
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyFrontends"
+// +kubebuilder:printcolumn:name="Config",type="string",JSONPath=".status.conditions[?(@.type==\"ConfigGenerated\")].status"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // FrontendEnvironment is the Schema for the FrontendEnvironments API
@@ -193,6 +344,14 @@ func init() {
 	SchemeBuilder.Register(&FrontendEnvironment{}, &FrontendEnvironmentList{})
 }
 
+func (i *FrontendEnvironment) GetConditions() []metav1.Condition {
+	return i.Status.Conditions
+}
+
+func (i *FrontendEnvironment) SetConditions(conditions []metav1.Condition) {
+	i.Status.Conditions = conditions
+}
+
 // GetLabels returns a base set of labels relating to the ClowdApp.
 func (i *FrontendEnvironment) GetLabels() map[string]string {
 	if i.Labels == nil {
@@ -229,6 +388,24 @@ func (i *FrontendEnvironment) GenerateTargetNamespace() string {
 	return i.Name
 }
 
+// UsesGatewayAPI returns true when the environment is exposed with HTTPRoutes. HTTPRoutes can
+// not enforce a whitelist, environments stored with both keep their Ingress objects until the
+// whitelist is removed, see GatewayRoutingBlocked.
+func (i *FrontendEnvironment) UsesGatewayAPI() bool {
+	return i.Spec.RoutingMode == RoutingModeGatewayAPI && !i.GatewayRoutingBlocked()
+}
+
+// GatewayRoutingBlocked returns true when the environment asks for Gateway API routing together
+// with a whitelist, which is rejected on admission but may be set on environments stored before.
+func (i *FrontendEnvironment) GatewayRoutingBlocked() bool {
+	return i.Spec.RoutingMode == RoutingModeGatewayAPI && len(i.Spec.Whitelist) != 0
+}
+
+// UsesRoutes returns true when the environment is exposed with OpenShift Routes
+func (i *FrontendEnvironment) UsesRoutes() bool {
+	return i.Spec.RoutingMode == RoutingModeRoute
+}
+
 // MakeOwnerReference defines the owner reference pointing to the Frontend resource.
 func (i *FrontendEnvironment) MakeOwnerReference() metav1.OwnerReference {
 	return metav1.OwnerReference{
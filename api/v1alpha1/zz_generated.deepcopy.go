@@ -25,6 +25,7 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -89,6 +90,51 @@ func (in *Analytics) DeepCopy() *Analytics {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetSource) DeepCopyInto(out *AssetSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetSource.
+func (in *AssetSource) DeepCopy() *AssetSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingConfig) DeepCopyInto(out *AutoscalingConfig) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetMemoryUtilizationPercentage != nil {
+		in, out := &in.TargetMemoryUtilizationPercentage, &out.TargetMemoryUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingConfig.
+func (in *AutoscalingConfig) DeepCopy() *AutoscalingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BaseWidgetDashboardTemplate) DeepCopyInto(out *BaseWidgetDashboardTemplate) {
 	*out = *in
@@ -293,6 +339,114 @@ func (in *BundleStatus) DeepCopy() *BundleStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CacheBustStatus) DeepCopyInto(out *CacheBustStatus) {
+	*out = *in
+	if in.URLs != nil {
+		in, out := &in.URLs, &out.URLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PurgeIDs != nil {
+		in, out := &in.PurgeIDs, &out.PurgeIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PurgeTime != nil {
+		in, out := &in.PurgeTime, &out.PurgeTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheBustStatus.
+func (in *CacheBustStatus) DeepCopy() *CacheBustStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CacheBustStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CachePurgeConfig) DeepCopyInto(out *CachePurgeConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CachePurgeConfig.
+func (in *CachePurgeConfig) DeepCopy() *CachePurgeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CachePurgeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CaddyConfig) DeepCopyInto(out *CaddyConfig) {
+	*out = *in
+	if in.Redirects != nil {
+		in, out := &in.Redirects, &out.Redirects
+		*out = make([]CaddyRedirect, len(*in))
+		copy(*out, *in)
+	}
+	if in.Handles != nil {
+		in, out := &in.Handles, &out.Handles
+		*out = make([]CaddyHandle, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CaddyConfig.
+func (in *CaddyConfig) DeepCopy() *CaddyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CaddyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CaddyHandle) DeepCopyInto(out *CaddyHandle) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CaddyHandle.
+func (in *CaddyHandle) DeepCopy() *CaddyHandle {
+	if in == nil {
+		return nil
+	}
+	out := new(CaddyHandle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CaddyRedirect) DeepCopyInto(out *CaddyRedirect) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CaddyRedirect.
+func (in *CaddyRedirect) DeepCopy() *CaddyRedirect {
+	if in == nil {
+		return nil
+	}
+	out := new(CaddyRedirect)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChromeNavItem) DeepCopyInto(out *ChromeNavItem) {
 	*out = *in
@@ -524,7 +678,7 @@ func (in *FrontendEnvironment) DeepCopyInto(out *FrontendEnvironment) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendEnvironment.
@@ -594,6 +748,16 @@ func (in *FrontendEnvironmentSpec) DeepCopyInto(out *FrontendEnvironmentSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(GatewayParentRef)
+		**out = **in
+	}
+	if in.RouteTLS != nil {
+		in, out := &in.RouteTLS, &out.RouteTLS
+		*out = new(RouteTLSConfig)
+		**out = **in
+	}
 	if in.Whitelist != nil {
 		in, out := &in.Whitelist, &out.Whitelist
 		*out = make([]string, len(*in))
@@ -609,6 +773,11 @@ func (in *FrontendEnvironmentSpec) DeepCopyInto(out *FrontendEnvironmentSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CachePurge != nil {
+		in, out := &in.CachePurge, &out.CachePurge
+		*out = new(CachePurgeConfig)
+		**out = **in
+	}
 	if in.TargetNamespaces != nil {
 		in, out := &in.TargetNamespaces, &out.TargetNamespaces
 		*out = make([]string, len(*in))
@@ -632,11 +801,38 @@ func (in *FrontendEnvironmentSpec) DeepCopyInto(out *FrontendEnvironmentSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.HeaderPolicies != nil {
+		in, out := &in.HeaderPolicies, &out.HeaderPolicies
+		*out = make([]HeaderPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ObjectStore != nil {
+		in, out := &in.ObjectStore, &out.ObjectStore
+		*out = new(ObjectStoreConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImageResolution != nil {
+		in, out := &in.ImageResolution, &out.ImageResolution
+		*out = new(ImageResolutionConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.DefaultReplicas != nil {
 		in, out := &in.DefaultReplicas, &out.DefaultReplicas
 		*out = new(int32)
 		**out = **in
 	}
+	if in.DefaultAutoscaling != nil {
+		in, out := &in.DefaultAutoscaling, &out.DefaultAutoscaling
+		*out = new(AutoscalingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultPodDisruptionBudget != nil {
+		in, out := &in.DefaultPodDisruptionBudget, &out.DefaultPodDisruptionBudget
+		*out = new(PodDisruptionBudgetConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Bundles != nil {
 		in, out := &in.Bundles, &out.Bundles
 		*out = new([]FrontendBundles)
@@ -675,6 +871,23 @@ func (in *FrontendEnvironmentSpec) DeepCopy() *FrontendEnvironmentSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FrontendEnvironmentStatus) DeepCopyInto(out *FrontendEnvironmentStatus) {
 	*out = *in
+	if in.FeoConfigEnabledFrontends != nil {
+		in, out := &in.FeoConfigEnabledFrontends, &out.FeoConfigEnabledFrontends
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]GeneratedConfigMap, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendEnvironmentStatus.
@@ -707,6 +920,21 @@ func (in *FrontendInfo) DeepCopy() *FrontendInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrontendJobsConfig) DeepCopyInto(out *FrontendJobsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendJobsConfig.
+func (in *FrontendJobsConfig) DeepCopy() *FrontendJobsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FrontendJobsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FrontendList) DeepCopyInto(out *FrontendList) {
 	*out = *in
@@ -739,6 +967,21 @@ func (in *FrontendList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrontendRouteStatus) DeepCopyInto(out *FrontendRouteStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendRouteStatus.
+func (in *FrontendRouteStatus) DeepCopy() *FrontendRouteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FrontendRouteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FrontendServiceCategory) DeepCopyInto(out *FrontendServiceCategory) {
 	*out = *in
@@ -870,11 +1113,21 @@ func (in *FrontendSpec) DeepCopyInto(out *FrontendSpec) {
 			}
 		}
 	}
+	if in.AssetSources != nil {
+		in, out := &in.AssetSources, &out.AssetSources
+		*out = make([]AssetSource, len(*in))
+		copy(*out, *in)
+	}
 	if in.AkamaiCacheBustPaths != nil {
 		in, out := &in.AkamaiCacheBustPaths, &out.AkamaiCacheBustPaths
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AkamaiCacheBustTags != nil {
+		in, out := &in.AkamaiCacheBustTags, &out.AkamaiCacheBustTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.SearchEntries != nil {
 		in, out := &in.SearchEntries, &out.SearchEntries
 		*out = make([]*SearchEntry, len(*in))
@@ -924,6 +1177,43 @@ func (in *FrontendSpec) DeepCopyInto(out *FrontendSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Caddy != nil {
+		in, out := &in.Caddy, &out.Caddy
+		*out = new(CaddyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HeaderPolicies != nil {
+		in, out := &in.HeaderPolicies, &out.HeaderPolicies
+		*out = make([]HeaderPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = new(FrontendJobsConfig)
+		**out = **in
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendSpec.
@@ -947,6 +1237,31 @@ func (in *FrontendStatus) DeepCopyInto(out *FrontendStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]FrontendRouteStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.CacheBust != nil {
+		in, out := &in.CacheBust, &out.CacheBust
+		*out = new(CacheBustStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PushCache != nil {
+		in, out := &in.PushCache, &out.PushCache
+		*out = new(PushCacheStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(ImageStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendStatus.
@@ -959,6 +1274,97 @@ func (in *FrontendStatus) DeepCopy() *FrontendStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayParentRef) DeepCopyInto(out *GatewayParentRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParentRef.
+func (in *GatewayParentRef) DeepCopy() *GatewayParentRef {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParentRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratedConfigMap) DeepCopyInto(out *GeneratedConfigMap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratedConfigMap.
+func (in *GeneratedConfigMap) DeepCopy() *GeneratedConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratedConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderPolicy) DeepCopyInto(out *HeaderPolicy) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderPolicy.
+func (in *HeaderPolicy) DeepCopy() *HeaderPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageResolutionConfig) DeepCopyInto(out *ImageResolutionConfig) {
+	*out = *in
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageResolutionConfig.
+func (in *ImageResolutionConfig) DeepCopy() *ImageResolutionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageResolutionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageStatus) DeepCopyInto(out *ImageStatus) {
+	*out = *in
+	if in.ResolveTime != nil {
+		in, out := &in.ResolveTime, &out.ResolveTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageStatus.
+func (in *ImageStatus) DeepCopy() *ImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LeafBundleNavItem) DeepCopyInto(out *LeafBundleNavItem) {
 	*out = *in
@@ -1080,6 +1486,36 @@ func (in *NavigationSegment) DeepCopy() *NavigationSegment {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreConfig) DeepCopyInto(out *ObjectStoreConfig) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreConfig.
+func (in *ObjectStoreConfig) DeepCopy() *ObjectStoreConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Permission) DeepCopyInto(out *Permission) {
 	*out = *in
@@ -1105,6 +1541,143 @@ func (in *Permission) DeepCopy() *Permission {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetConfig) DeepCopyInto(out *PodDisruptionBudgetConfig) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetConfig.
+func (in *PodDisruptionBudgetConfig) DeepCopy() *PodDisruptionBudgetConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PushCacheRun) DeepCopyInto(out *PushCacheRun) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PushCacheRun.
+func (in *PushCacheRun) DeepCopy() *PushCacheRun {
+	if in == nil {
+		return nil
+	}
+	out := new(PushCacheRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PushCacheStatus) DeepCopyInto(out *PushCacheStatus) {
+	*out = *in
+	if in.LastRun != nil {
+		in, out := &in.LastRun, &out.LastRun
+		*out = new(PushCacheRun)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]PushCacheRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PushCacheStatus.
+func (in *PushCacheStatus) DeepCopy() *PushCacheStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PushCacheStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutConfig) DeepCopyInto(out *RolloutConfig) {
+	*out = *in
+	if in.CanaryWeight != nil {
+		in, out := &in.CanaryWeight, &out.CanaryWeight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CanaryReplicas != nil {
+		in, out := &in.CanaryReplicas, &out.CanaryReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SoakDuration != nil {
+		in, out := &in.SoakDuration, &out.SoakDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ProgressDeadline != nil {
+		in, out := &in.ProgressDeadline, &out.ProgressDeadline
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutConfig.
+func (in *RolloutConfig) DeepCopy() *RolloutConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ReadyTime != nil {
+		in, out := &in.ReadyTime, &out.ReadyTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Route) DeepCopyInto(out *Route) {
 	*out = *in
@@ -1137,6 +1710,21 @@ func (in *Route) DeepCopy() *Route {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteTLSConfig) DeepCopyInto(out *RouteTLSConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTLSConfig.
+func (in *RouteTLSConfig) DeepCopy() *RouteTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SearchEntry) DeepCopyInto(out *SearchEntry) {
 	*out = *in
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+)
+
+func runDiff(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var envName string
+	var exitCode bool
+	flags.StringVar(&envName, "env", "", "Name of the FrontendEnvironment to compare, required when an input contains more than one.")
+	flags.BoolVar(&exitCode, "exit-code", false, "Fail when the rendered config differs.")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: feo diff [flags] <base> <head>")
+		fmt.Fprintln(stderr, "")
+		fmt.Fprintln(stderr, "Inputs are directories of manifests or cluster:<kubeconfig context>.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return fmt.Errorf("expected a base and a head input, got %d", flags.NArg())
+	}
+
+	files := [2]map[string]string{}
+	for i, source := range flags.Args() {
+		m, err := loadSource(source)
+		if err != nil {
+			return fmt.Errorf("%s: %w", source, err)
+		}
+		rendered, err := renderManifests(m, envName, io.Discard)
+		if err != nil {
+			return fmt.Errorf("%s: %w", source, err)
+		}
+		files[i] = rendered.Files()
+	}
+
+	changes := diffConfigFiles(files[0], files[1])
+	names := []string{}
+	for name := range changes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintln(stdout, name)
+		for _, c := range changes[name] {
+			fmt.Fprintf(stdout, "  %s\n", c)
+		}
+	}
+
+	if exitCode && len(changes) > 0 {
+		return fmt.Errorf("rendered config differs in %d files", len(changes))
+	}
+	return nil
+}
+
+// diffConfigFiles compares two sets of rendered config files, files without changes are left out
+func diffConfigFiles(base, head map[string]string) map[string][]change {
+	changes := map[string][]change{}
+	for name, baseContent := range base {
+		headContent, ok := head[name]
+		if !ok {
+			changes[name] = []change{{Kind: changeRemoved}}
+			continue
+		}
+		if fileChanges := diffConfigFile(baseContent, headContent); len(fileChanges) > 0 {
+			changes[name] = fileChanges
+		}
+	}
+	for name := range head {
+		if _, ok := base[name]; !ok {
+			changes[name] = []change{{Kind: changeAdded}}
+		}
+	}
+	return changes
+}
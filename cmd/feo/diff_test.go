@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	if err := run([]string{"diff", "testdata/diff/base", "testdata/diff/head"}, stdout, &bytes.Buffer{}); err != nil {
+		t.Fatalf("diff failed: %v", err)
+	}
+
+	expected := []string{
+		"bundles.json",
+		"  removed  [insights].navItems[groups]",
+		"  moved    [insights].navItems[inventory]: index 0 -> 1",
+		`  added    [insights].navItems[workspaces]: {"bundleSegmentRef":"inventory-insights","frontendRef":"inventory","href":"/insights/inventory/workspaces","id":"work...`,
+		"fed-modules.json",
+		`  added    advisor: {"cdnPath":"/apps/advisor/","fullProfile":false,"manifestLocation":"/apps/advisor/fed-mods.json","modules":[{"id":"ad...`,
+		"search-index.json",
+		`  changed  [inventory-test-env-inventory].description: "Systems registered to Red Hat Insights" -> "Hosts registered to Red Hat Insights"`,
+	}
+	got := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected diff output:\n%s\nexpected:\n%s", stdout.String(), strings.Join(expected, "\n"))
+	}
+}
+
+func TestDiffExitCode(t *testing.T) {
+	err := run([]string{"diff", "-exit-code", "testdata/diff/base", "testdata/diff/base"}, &bytes.Buffer{}, &bytes.Buffer{})
+	if err != nil {
+		t.Errorf("expected no error for identical inputs, got %v", err)
+	}
+
+	err = run([]string{"diff", "-exit-code", "testdata/diff/base", "testdata/diff/head"}, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil {
+		t.Error("expected an error for differing inputs")
+	}
+}
+
+func TestDiffLists(t *testing.T) {
+	base := `[{"id":"a","title":"A"},{"id":"b","title":"B"},{"id":"c","title":"C"}]`
+	head := `[{"id":"a","title":"A"},{"id":"c","title":"C"},{"id":"b","title":"B2"}]`
+
+	changes := diffConfigFile(base, head)
+
+	expected := []string{
+		"moved    [b]: index 1 -> 2",
+		`changed  [b].title: "B" -> "B2"`,
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %v", len(expected), changes)
+	}
+	for i, c := range changes {
+		if c.String() != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], c.String())
+		}
+	}
+}
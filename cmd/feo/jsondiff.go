@@ -0,0 +1,248 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// changeKind is the kind of a difference between two rendered config values
+type changeKind string
+
+const (
+	changeAdded   changeKind = "added"
+	changeRemoved changeKind = "removed"
+	changeChanged changeKind = "changed"
+	changeMoved   changeKind = "moved"
+)
+
+type change struct {
+	Kind   changeKind
+	Path   string
+	Detail string
+}
+
+func (c change) String() string {
+	if c.Path == "" {
+		return string(c.Kind)
+	}
+	if c.Detail == "" {
+		return fmt.Sprintf("%-8s %s", c.Kind, c.Path)
+	}
+	return fmt.Sprintf("%-8s %s: %s", c.Kind, c.Path, c.Detail)
+}
+
+// identityKeys are the fields tried, in order, to match the elements of two lists of objects,
+// e.g. bundles and nav items by id, fed-modules routes by pathname. Lists whose elements do
+// not all have a distinct value for one of the keys are compared by index.
+var identityKeys = []string{"id", "segmentId", "pathname", "href", "url", "scope", "title"}
+
+// diffConfigFile compares the content of a rendered config file, JSON files are compared
+// semantically and everything else as plain text.
+func diffConfigFile(base, head string) []change {
+	var baseValue, headValue interface{}
+	if json.Unmarshal([]byte(base), &baseValue) != nil || json.Unmarshal([]byte(head), &headValue) != nil {
+		if base != head {
+			return []change{{Kind: changeChanged}}
+		}
+		return nil
+	}
+	return diffValues("", baseValue, headValue)
+}
+
+func diffValues(path string, base, head interface{}) []change {
+	baseObject, baseIsObject := base.(map[string]interface{})
+	headObject, headIsObject := head.(map[string]interface{})
+	if baseIsObject && headIsObject {
+		return diffObjects(path, baseObject, headObject)
+	}
+
+	baseList, baseIsList := base.([]interface{})
+	headList, headIsList := head.([]interface{})
+	if baseIsList && headIsList {
+		return diffLists(path, baseList, headList)
+	}
+
+	if !reflect.DeepEqual(base, head) {
+		return []change{{Kind: changeChanged, Path: path, Detail: fmt.Sprintf("%s -> %s", compactJSON(base), compactJSON(head))}}
+	}
+	return nil
+}
+
+func diffObjects(path string, base, head map[string]interface{}) []change {
+	keys := map[string]bool{}
+	for k := range base {
+		keys[k] = true
+	}
+	for k := range head {
+		keys[k] = true
+	}
+	sortedKeys := []string{}
+	for k := range keys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+
+	changes := []change{}
+	for _, k := range sortedKeys {
+		fieldPath := joinPath(path, k)
+		baseValue, inBase := base[k]
+		headValue, inHead := head[k]
+		switch {
+		case !inBase:
+			changes = append(changes, change{Kind: changeAdded, Path: fieldPath, Detail: compactJSON(headValue)})
+		case !inHead:
+			changes = append(changes, change{Kind: changeRemoved, Path: fieldPath})
+		default:
+			changes = append(changes, diffValues(fieldPath, baseValue, headValue)...)
+		}
+	}
+	return changes
+}
+
+func diffLists(path string, base, head []interface{}) []change {
+	key := listIdentityKey(base, head)
+	if key == "" {
+		return diffListsByIndex(path, base, head)
+	}
+
+	baseIDs := listIdentities(base, key)
+	headIDs := listIdentities(head, key)
+	baseIndex := map[string]int{}
+	for i, id := range baseIDs {
+		baseIndex[id] = i
+	}
+	headIndex := map[string]int{}
+	for i, id := range headIDs {
+		headIndex[id] = i
+	}
+
+	// elements kept in the same relative order are not reported as moved
+	inOrder := map[string]bool{}
+	for _, id := range longestCommonSubsequence(baseIDs, headIDs) {
+		inOrder[id] = true
+	}
+
+	changes := []change{}
+	for _, id := range baseIDs {
+		if _, ok := headIndex[id]; !ok {
+			changes = append(changes, change{Kind: changeRemoved, Path: fmt.Sprintf("%s[%s]", path, id)})
+		}
+	}
+	for i, id := range headIDs {
+		elementPath := fmt.Sprintf("%s[%s]", path, id)
+		j, ok := baseIndex[id]
+		if !ok {
+			changes = append(changes, change{Kind: changeAdded, Path: elementPath, Detail: compactJSON(head[i])})
+			continue
+		}
+		if !inOrder[id] {
+			changes = append(changes, change{Kind: changeMoved, Path: elementPath, Detail: fmt.Sprintf("index %d -> %d", j, i)})
+		}
+		changes = append(changes, diffValues(elementPath, base[j], head[i])...)
+	}
+	return changes
+}
+
+func diffListsByIndex(path string, base, head []interface{}) []change {
+	changes := []change{}
+	for i := 0; i < len(base) || i < len(head); i++ {
+		elementPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(base):
+			changes = append(changes, change{Kind: changeAdded, Path: elementPath, Detail: compactJSON(head[i])})
+		case i >= len(head):
+			changes = append(changes, change{Kind: changeRemoved, Path: elementPath})
+		default:
+			changes = append(changes, diffValues(elementPath, base[i], head[i])...)
+		}
+	}
+	return changes
+}
+
+// listIdentityKey returns the first identity key that is set and unique on all elements of both lists
+func listIdentityKey(base, head []interface{}) string {
+	for _, key := range identityKeys {
+		if hasUniqueKey(base, key) && hasUniqueKey(head, key) {
+			return key
+		}
+	}
+	return ""
+}
+
+func hasUniqueKey(list []interface{}, key string) bool {
+	seen := map[string]bool{}
+	for _, element := range list {
+		object, ok := element.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		value, ok := object[key].(string)
+		if !ok || value == "" || seen[value] {
+			return false
+		}
+		seen[value] = true
+	}
+	return true
+}
+
+func listIdentities(list []interface{}, key string) []string {
+	ids := []string{}
+	for _, element := range list {
+		ids = append(ids, element.(map[string]interface{})[key].(string))
+	}
+	return ids
+}
+
+func longestCommonSubsequence(a, b []string) []string {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	res := []string{}
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			res = append(res, a[i])
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return res
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+func compactJSON(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	res := string(data)
+	// keep the output readable for large added subtrees like whole bundles
+	if len(res) > 120 {
+		res = res[:117] + "..."
+	}
+	return strings.TrimSpace(res)
+}
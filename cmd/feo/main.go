@@ -0,0 +1,63 @@
+/*
+Copyright 2025 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command feo runs the frontend-operator config generation outside of a cluster.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `feo runs the frontend-operator config generation outside of a cluster.
+
+Usage:
+  feo <command> [flags]
+
+Commands:
+  render   Render the generated config of a FrontendEnvironment from YAML manifests
+  diff     Compare the generated config of two manifest directories or clusters
+
+Run "feo <command> -h" for the flags of a command.
+`
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "feo: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return fmt.Errorf("no command given")
+	}
+
+	switch args[0] {
+	case "render":
+		return runRender(args[1:], stdout, stderr)
+	case "diff":
+		return runDiff(args[1:], stdout, stderr)
+	case "help", "-h", "--help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	}
+
+	fmt.Fprint(stderr, usage)
+	return fmt.Errorf("unknown command %q", args[0])
+}
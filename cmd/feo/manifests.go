@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(crd.AddToScheme(scheme))
+}
+
+// manifests holds the operator resources read from a directory
+type manifests struct {
+	Frontends    []crd.Frontend
+	Environments []crd.FrontendEnvironment
+	Bundles      []crd.Bundle
+}
+
+// loadManifests reads every YAML and JSON file below dir. Documents of other kinds,
+// like the rest of an application's deployment, are skipped.
+func loadManifests(dir string) (*manifests, error) {
+	m := &manifests{}
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		reader := utilyaml.NewYAMLReader(bufio.NewReader(f))
+		for {
+			doc, err := reader.Read()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			if len(bytes.TrimSpace(doc)) == 0 {
+				continue
+			}
+
+			obj, _, err := decoder.Decode(doc, nil, nil)
+			if runtime.IsNotRegisteredError(err) || runtime.IsMissingKind(err) || runtime.IsMissingVersion(err) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+
+			switch o := obj.(type) {
+			case *crd.Frontend:
+				m.Frontends = append(m.Frontends, *o)
+			case *crd.FrontendList:
+				m.Frontends = append(m.Frontends, o.Items...)
+			case *crd.FrontendEnvironment:
+				m.Environments = append(m.Environments, *o)
+			case *crd.FrontendEnvironmentList:
+				m.Environments = append(m.Environments, o.Items...)
+			case *crd.Bundle:
+				m.Bundles = append(m.Bundles, *o)
+			case *crd.BundleList:
+				m.Bundles = append(m.Bundles, o.Items...)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// environment returns the named FrontendEnvironment, the name can be omitted when
+// the manifests contain a single environment.
+func (m *manifests) environment(name string) (*crd.FrontendEnvironment, error) {
+	if name == "" {
+		if len(m.Environments) != 1 {
+			return nil, fmt.Errorf("found %d FrontendEnvironments, select one with -env", len(m.Environments))
+		}
+		return &m.Environments[0], nil
+	}
+	for i := range m.Environments {
+		if m.Environments[i].Name == name {
+			return &m.Environments[i], nil
+		}
+	}
+	return nil, fmt.Errorf("FrontendEnvironment %q not found", name)
+}
+
+// frontendsInEnv mirrors the spec.envName lookup of the operator
+func (m *manifests) frontendsInEnv(envName string) *crd.FrontendList {
+	feList := &crd.FrontendList{}
+	for _, frontend := range m.Frontends {
+		if frontend.Spec.EnvName == envName {
+			feList.Items = append(feList.Items, frontend)
+		}
+	}
+	sort.Slice(feList.Items, func(i, j int) bool {
+		if feList.Items[i].Namespace != feList.Items[j].Namespace {
+			return feList.Items[i].Namespace < feList.Items[j].Namespace
+		}
+		return feList.Items[i].Name < feList.Items[j].Name
+	})
+	return feList
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/RedHatInsights/frontend-operator/controllers"
+)
+
+func runRender(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("render", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var inputDir, outputDir, envName string
+	var strict bool
+	flags.StringVar(&inputDir, "f", ".", "Directory with the Frontend, FrontendEnvironment and Bundle manifests.")
+	flags.StringVar(&outputDir, "o", "rendered", "Directory the generated config files are written to.")
+	flags.StringVar(&envName, "env", "", "Name of the FrontendEnvironment to render, required when the manifests contain more than one.")
+	flags.BoolVar(&strict, "strict", false, "Fail when configuration is left out of the generated config.")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	m, err := loadManifests(inputDir)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := renderManifests(m, envName, stderr)
+	if err != nil {
+		return err
+	}
+
+	if err := writeFiles(outputDir, rendered.Files(), stdout); err != nil {
+		return err
+	}
+
+	return checkWarnings(rendered, strict, stderr)
+}
+
+// renderManifests renders the config of the selected environment
+func renderManifests(m *manifests, envName string, stderr io.Writer) (*controllers.RenderedConfig, error) {
+	feEnv, err := m.environment(envName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(m.Bundles) > 0 {
+		// the operator builds the navigation from the bundleSegments of the Frontends
+		fmt.Fprintf(stderr, "ignoring %d Bundle resources, navigation is generated from Frontend bundleSegments\n", len(m.Bundles))
+	}
+
+	return controllers.RenderConfig(feEnv, m.frontendsInEnv(feEnv.Name))
+}
+
+func writeFiles(dir string, files map[string]string, stdout io.Writer) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	names := []string{}
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(files[name]), 0o644); err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, path)
+	}
+	return nil
+}
+
+func checkWarnings(rendered *controllers.RenderedConfig, strict bool, stderr io.Writer) error {
+	warnings := rendered.Warnings()
+	for _, warning := range warnings {
+		fmt.Fprintf(stderr, "warning: %s\n", warning)
+	}
+	if strict && len(warnings) > 0 {
+		return fmt.Errorf("%d configuration warnings", len(warnings))
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	out := t.TempDir()
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	if err := run([]string{"render", "-f", "testdata/render", "-o", out}, stdout, stderr); err != nil {
+		t.Fatalf("render failed: %v\n%s", err, stderr.String())
+	}
+
+	for _, name := range []string{"Caddyfile", "bundles.json", "fed-modules.json", "search-index.json", "service-tiles.json", "sso-config.json"} {
+		if _, err := os.Stat(filepath.Join(out, name)); err != nil {
+			t.Errorf("expected %s to be rendered: %v", name, err)
+		}
+	}
+
+	fedModules, err := os.ReadFile(filepath.Join(out, "fed-modules.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"inventory":{"manifestLocation":"/apps/inventory/fed-mods.json","modules":[{"id":"inventory","module":"./RootApp","routes":[{"pathname":"/insights/inventory"}]}],"fullProfile":false,"cdnPath":"/apps/inventory/"}}`
+	if string(fedModules) != expected {
+		t.Errorf("expected fed-modules.json to only contain the test-env frontend, got %s", fedModules)
+	}
+
+	if !strings.Contains(stderr.String(), `warning: boot/inventory: service tile lost-tile: unknown group "terraform" in section "automation"`) {
+		t.Errorf("expected a warning for the skipped tile, got %q", stderr.String())
+	}
+}
+
+func TestRenderStrict(t *testing.T) {
+	err := run([]string{"render", "-f", "testdata/render", "-o", t.TempDir(), "-strict"}, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || err.Error() != "1 configuration warnings" {
+		t.Errorf("expected strict mode to fail on the skipped tile, got %v", err)
+	}
+}
+
+func TestRenderUnknownEnvironment(t *testing.T) {
+	err := run([]string{"render", "-f", "testdata/render", "-o", t.TempDir(), "-env", "missing"}, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || err.Error() != `FrontendEnvironment "missing" not found` {
+		t.Errorf("expected an unknown environment error, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+const clusterSourcePrefix = "cluster:"
+
+// loadSource reads the manifests of an input, either a directory or a cluster given as
+// "cluster:<kubeconfig context>", the current context is used when it is left empty.
+func loadSource(source string) (*manifests, error) {
+	if !strings.HasPrefix(source, clusterSourcePrefix) {
+		return loadManifests(source)
+	}
+	return loadClusterManifests(strings.TrimPrefix(source, clusterSourcePrefix))
+}
+
+func loadClusterManifests(kubeContext string) (*manifests, error) {
+	cfg, err := config.GetConfigWithContext(kubeContext)
+	if err != nil {
+		return nil, err
+	}
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	frontends := &crd.FrontendList{}
+	if err := c.List(ctx, frontends); err != nil {
+		return nil, err
+	}
+	environments := &crd.FrontendEnvironmentList{}
+	if err := c.List(ctx, environments); err != nil {
+		return nil, err
+	}
+	bundles := &crd.BundleList{}
+	if err := c.List(ctx, bundles); err != nil {
+		return nil, err
+	}
+
+	return &manifests{
+		Frontends:    frontends.Items,
+		Environments: environments.Items,
+		Bundles:      bundles.Items,
+	}, nil
+}
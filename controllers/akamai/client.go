@@ -0,0 +1,202 @@
+/*
+Copyright 2025 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package akamai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Purge actions, invalidate marks the content stale while delete removes it
+const (
+	ActionInvalidate = "invalidate"
+	ActionDelete     = "delete"
+)
+
+// Purge object types
+const (
+	TypeURL = "url"
+	TypeTag = "tag"
+)
+
+// Networks the content is purged from
+const (
+	NetworkProduction = "production"
+	NetworkStaging    = "staging"
+)
+
+// maxRequestBodySize is the Fast Purge limit on the size of a request body, larger purges
+// are split into batches
+const maxRequestBodySize = 50000
+
+// PurgeRequest purges the URLs or cache tags in Objects
+type PurgeRequest struct {
+	Action  string
+	Type    string
+	Network string
+	Objects []string
+}
+
+// PurgeResult collects the responses to the batches of a purge
+type PurgeResult struct {
+	PurgeIDs []string
+	// EstimatedSeconds until the purge completes on the whole network
+	EstimatedSeconds int
+}
+
+// Client calls the Fast Purge API with EdgeGrid signed requests
+type Client struct {
+	credentials Credentials
+	// BaseURL of the API, defaults to the host of the credentials
+	BaseURL    string
+	HTTPClient *http.Client
+
+	now   func() time.Time
+	nonce func() (string, error)
+}
+
+// NewClient returns a Fast Purge client for the credentials
+func NewClient(credentials Credentials) *Client {
+	return &Client{
+		credentials: credentials,
+		BaseURL:     "https://" + credentials.Host,
+		HTTPClient:  &http.Client{Timeout: 30 * time.Second},
+		now:         time.Now,
+		nonce:       newNonce,
+	}
+}
+
+type purgeBody struct {
+	Objects []string `json:"objects"`
+}
+
+type purgeResponse struct {
+	HTTPStatus       int    `json:"httpStatus"`
+	Detail           string `json:"detail"`
+	Title            string `json:"title"`
+	EstimatedSeconds int    `json:"estimatedSeconds"`
+	PurgeID          string `json:"purgeId"`
+	SupportID        string `json:"supportId"`
+}
+
+// batchObjects splits the objects into batches whose request bodies fit the API limit
+func batchObjects(objects []string) ([][]string, error) {
+	emptySize := len(`{"objects":[]}`)
+	batches := [][]string{}
+	batch := []string{}
+	size := emptySize
+
+	for _, object := range objects {
+		encoded, err := json.Marshal(object)
+		if err != nil {
+			return nil, err
+		}
+		objectSize := len(encoded)
+		if emptySize+objectSize > maxRequestBodySize {
+			return nil, fmt.Errorf("purge object %q exceeds the request size limit", object)
+		}
+		if len(batch) != 0 {
+			objectSize++ // separating comma
+		}
+		if size+objectSize > maxRequestBodySize {
+			batches = append(batches, batch)
+			batch = []string{}
+			size = emptySize
+			objectSize = len(encoded)
+		}
+		batch = append(batch, object)
+		size += objectSize
+	}
+	if len(batch) != 0 {
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
+
+// Purge sends the objects in as many batches as the request size limit requires
+func (c *Client) Purge(ctx context.Context, request PurgeRequest) (*PurgeResult, error) {
+	batches, err := batchObjects(request.Objects)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PurgeResult{}
+	for _, batch := range batches {
+		response, err := c.purgeBatch(ctx, request, batch)
+		if err != nil {
+			return result, err
+		}
+		result.PurgeIDs = append(result.PurgeIDs, response.PurgeID)
+		if response.EstimatedSeconds > result.EstimatedSeconds {
+			result.EstimatedSeconds = response.EstimatedSeconds
+		}
+	}
+	return result, nil
+}
+
+func (c *Client) purgeBatch(ctx context.Context, request PurgeRequest, objects []string) (*purgeResponse, error) {
+	body, err := json.Marshal(purgeBody{Objects: objects})
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/ccu/v3/%s/%s/%s", c.BaseURL, request.Action, request.Type, request.Network)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := c.nonce()
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", c.credentials.authorization(req, body, c.now(), nonce))
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	response := &purgeResponse{}
+	// Errors from the edge are not always JSON, the status code is reported either way
+	_ = json.Unmarshal(responseBody, response)
+
+	if resp.StatusCode != http.StatusCreated {
+		detail := response.Detail
+		if detail == "" {
+			detail = response.Title
+		}
+		if detail == "" {
+			detail = http.StatusText(resp.StatusCode)
+		}
+		if response.SupportID != "" {
+			detail = fmt.Sprintf("%s (support ID %s)", detail, response.SupportID)
+		}
+		return nil, fmt.Errorf("%s %s purge failed with %d: %s", request.Action, request.Type, resp.StatusCode, detail)
+	}
+	return response, nil
+}
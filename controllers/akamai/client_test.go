@@ -0,0 +1,155 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// The requests and signatures of the EdgeGrid test suite
+func TestAuthorization(t *testing.T) {
+	credentials := Credentials{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+	}
+	timestamp := time.Date(2014, 3, 21, 19, 34, 21, 0, time.UTC)
+	nonce := "nonce-xx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+	prefix := "EG1-HMAC-SHA256 client_token=akab-client-token-xxx-xxxxxxxxxxxxxxxx;access_token=akab-access-token-xxx-xxxxxxxxxxxxxxxx;timestamp=20140321T19:34:21+0000;nonce=nonce-xx-xxxx-xxxx-xxxx-xxxxxxxxxxxx;signature="
+
+	for _, test := range []struct {
+		name      string
+		method    string
+		path      string
+		body      string
+		signature string
+	}{
+		{name: "simple GET", method: http.MethodGet, path: "/", signature: "tL+y4hxyHxgWVD30X3pWnGKHcPzmrIF+LThiAOhMxYU="},
+		{name: "POST", method: http.MethodPost, path: "/testapi/v1/t3", body: "datadatadatadatadatadatadatadata", signature: "hXm4iCxtpN22m4cbZb4lVLW5rhX8Ca82vCFqXzSTPe4="},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest(test.method, "https://"+credentials.Host+test.path, strings.NewReader(test.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if authorization := credentials.authorization(req, []byte(test.body), timestamp, nonce); authorization != prefix+test.signature {
+				t.Errorf("unexpected authorization %s", authorization)
+			}
+		})
+	}
+}
+
+func TestCredentialsFromSecret(t *testing.T) {
+	credentials, err := CredentialsFromSecret(map[string][]byte{
+		"host":          []byte("https://akab-host.luna.akamaiapis.net/\n"),
+		"client_token":  []byte("client"),
+		"client_secret": []byte("secret"),
+		"access_token":  []byte("access"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if credentials.Host != "akab-host.luna.akamaiapis.net" {
+		t.Errorf("expected a bare host, got %q", credentials.Host)
+	}
+
+	_, err = CredentialsFromSecret(map[string][]byte{"host": []byte("akab-host.luna.akamaiapis.net")})
+	if err == nil || err.Error() != "akamai credentials are missing access_token, client_secret, client_token" {
+		t.Errorf("expected the missing keys, got %v", err)
+	}
+}
+
+func TestBatchObjects(t *testing.T) {
+	objects := []string{}
+	for i := 0; i < 2000; i++ {
+		objects = append(objects, "https://console.example.com/apps/landing/js/"+strings.Repeat("x", 40)+".js")
+	}
+
+	batches, err := batchObjects(objects)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batches) < 2 {
+		t.Fatalf("expected the objects to be split, got %d batches", len(batches))
+	}
+	total := 0
+	for _, batch := range batches {
+		body, _ := json.Marshal(purgeBody{Objects: batch})
+		if len(body) > maxRequestBodySize {
+			t.Errorf("batch of %d bytes exceeds the limit", len(body))
+		}
+		total += len(batch)
+	}
+	if total != len(objects) {
+		t.Errorf("expected %d objects in the batches, got %d", len(objects), total)
+	}
+
+	if _, err := batchObjects([]string{strings.Repeat("x", maxRequestBodySize)}); err == nil {
+		t.Error("expected an object over the limit to be rejected")
+	}
+}
+
+func TestPurge(t *testing.T) {
+	var mu sync.Mutex
+	requests := []purgeBody{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/ccu/v3/delete/url/production" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "EG1-HMAC-SHA256 client_token=client;access_token=access;") {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		body := purgeBody{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		requests = append(requests, body)
+		id := len(requests)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(purgeResponse{HTTPStatus: 201, Detail: "Request accepted", EstimatedSeconds: 5 * id, PurgeID: "purge-" + strings.Repeat("x", id)})
+	}))
+	defer server.Close()
+
+	client := NewClient(Credentials{Host: "akab-host.luna.akamaiapis.net", ClientToken: "client", ClientSecret: "secret", AccessToken: "access"})
+	client.BaseURL = server.URL
+
+	objects := []string{}
+	for i := 0; i < 600; i++ {
+		objects = append(objects, "https://console.example.com/apps/landing/"+strings.Repeat("x", 60))
+	}
+	result, err := client.Purge(context.Background(), PurgeRequest{Action: ActionDelete, Type: TypeURL, Network: NetworkProduction, Objects: objects})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 2 || len(requests[0].Objects)+len(requests[1].Objects) != len(objects) {
+		t.Fatalf("expected the purge in 2 batches, got %d", len(requests))
+	}
+	if len(result.PurgeIDs) != 2 || result.EstimatedSeconds != 10 {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestPurgeRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"type":"https://problems.luna.akamaiapis.net/-/pep-authn/deny","title":"Not authorized","status":403,"detail":"The signature does not match","supportId":"18.abc"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Credentials{Host: "akab-host.luna.akamaiapis.net", ClientToken: "client", ClientSecret: "secret", AccessToken: "access"})
+	client.BaseURL = server.URL
+
+	_, err := client.Purge(context.Background(), PurgeRequest{Action: ActionInvalidate, Type: TypeTag, Network: NetworkStaging, Objects: []string{"landing"}})
+	if err == nil || err.Error() != "invalidate tag purge failed with 403: The signature does not match (support ID 18.abc)" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
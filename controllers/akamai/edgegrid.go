@@ -0,0 +1,125 @@
+/*
+Copyright 2025 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package akamai purges content from the Akamai CDN with the Fast Purge (CCU v3) API
+package akamai
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxSignedBodySize is the number of body bytes EdgeGrid hashes into the signature
+const maxSignedBodySize = 131072
+
+// Credentials of an Akamai API client
+type Credentials struct {
+	Host         string
+	ClientToken  string
+	ClientSecret string
+	AccessToken  string
+}
+
+// CredentialsFromSecret reads the credentials from the keys of the akamai secret
+func CredentialsFromSecret(data map[string][]byte) (Credentials, error) {
+	credentials := Credentials{
+		Host:         strings.TrimSpace(string(data["host"])),
+		ClientToken:  strings.TrimSpace(string(data["client_token"])),
+		ClientSecret: strings.TrimSpace(string(data["client_secret"])),
+		AccessToken:  strings.TrimSpace(string(data["access_token"])),
+	}
+
+	missing := []string{}
+	for key, value := range map[string]string{
+		"host":          credentials.Host,
+		"client_token":  credentials.ClientToken,
+		"client_secret": credentials.ClientSecret,
+		"access_token":  credentials.AccessToken,
+	} {
+		if value == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) != 0 {
+		sort.Strings(missing)
+		return credentials, fmt.Errorf("akamai credentials are missing %s", strings.Join(missing, ", "))
+	}
+
+	credentials.Host = strings.TrimSuffix(strings.TrimPrefix(credentials.Host, "https://"), "/")
+	return credentials, nil
+}
+
+// newNonce returns a random UUID, EdgeGrid rejects requests that reuse a nonce
+func newNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	h := hex.EncodeToString(b)
+	return fmt.Sprintf("%s-%s-%s-%s-%s", h[0:8], h[8:12], h[12:16], h[16:20], h[20:32]), nil
+}
+
+func hmacSHA256(key, message string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(message))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// authorization returns the EG1-HMAC-SHA256 Authorization header of the request, see
+// https://techdocs.akamai.com/developer/docs/authenticate-with-edgegrid
+func (c Credentials) authorization(req *http.Request, body []byte, timestamp time.Time, nonce string) string {
+	edgeTimestamp := timestamp.UTC().Format("20060102T15:04:05+0000")
+	authHeader := fmt.Sprintf("EG1-HMAC-SHA256 client_token=%s;access_token=%s;timestamp=%s;nonce=%s;",
+		c.ClientToken, c.AccessToken, edgeTimestamp, nonce)
+
+	contentHash := ""
+	if req.Method == http.MethodPost && len(body) != 0 {
+		if len(body) > maxSignedBodySize {
+			body = body[:maxSignedBodySize]
+		}
+		sum := sha256.Sum256(body)
+		contentHash = base64.StdEncoding.EncodeToString(sum[:])
+	}
+
+	pathAndQuery := req.URL.EscapedPath()
+	if req.URL.RawQuery != "" {
+		pathAndQuery += "?" + req.URL.RawQuery
+	}
+
+	// No headers are signed, the canonicalized headers are empty
+	dataToSign := strings.Join([]string{
+		req.Method,
+		req.URL.Scheme,
+		req.URL.Host,
+		pathAndQuery,
+		"",
+		contentHash,
+		authHeader,
+	}, "\t")
+
+	signingKey := hmacSHA256(c.ClientSecret, edgeTimestamp)
+	return authHeader + "signature=" + hmacSHA256(signingKey, dataToSign)
+}
@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	resCache "github.com/RedHatInsights/rhc-osdk-utils/resourceCache"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+	"github.com/go-logr/logr"
+	apps "k8s.io/api/apps/v1"
+	autoscaling "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// reconcileFrontendResources runs the per-Frontend reconciliation against the client the
+// same way the FrontendReconciler does
+func reconcileFrontendResources(t *testing.T, pClient client.Client, frontend *crd.Frontend, frontendEnvironment *crd.FrontendEnvironment) {
+	t.Helper()
+	ctx := context.Background()
+	log := logr.Discard()
+
+	cacheConfig := resCache.NewCacheConfig(scheme, nil, nil, resCache.Options{})
+	cache := resCache.NewObjectCache(ctx, pClient, &log, cacheConfig)
+	cache.AddPossibleGVKFromIdent(CoreDeployment, FrontendHPA, FrontendPDB, CoreService, WebIngress)
+	if frontendEnvironment.UsesGatewayAPI() {
+		cache.AddPossibleGVKFromIdent(FrontendHTTPRoute)
+	}
+	if frontendEnvironment.UsesRoutes() {
+		cache.AddPossibleGVKFromIdent(FrontendRoutes)
+	}
+
+	reconciliation := FrontendReconciliation{
+		Log:                 log,
+		Cache:               cache,
+		FrontendEnvironment: frontendEnvironment,
+		Ctx:                 ctx,
+		Frontend:            frontend,
+		Client:              pClient,
+	}
+	if err := reconciliation.run(); err != nil {
+		t.Fatalf("reconciliation failed: %v", err)
+	}
+	if err := cache.ApplyAll(); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if err := cache.Reconcile(frontend.GetUID(), client.MatchingLabels{"frontend": frontend.Name}); err != nil {
+		t.Fatalf("cleanup failed: %v", err)
+	}
+}
+
+func autoscalingTestObjects() (*crd.Frontend, *crd.FrontendEnvironment) {
+	frontend := &crd.Frontend{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "cloud.redhat.com/v1alpha1", Kind: "Frontend"},
+		ObjectMeta: metav1.ObjectMeta{Name: "landing", Namespace: "boot", UID: "landing-uid"},
+		Spec: crd.FrontendSpec{
+			EnvName:  "stage",
+			Image:    "quay.io/landing:abc",
+			Frontend: crd.FrontendInfo{Paths: []string{"/apps/landing"}},
+		},
+	}
+	frontendEnvironment := &crd.FrontendEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "stage"},
+		Spec: crd.FrontendEnvironmentSpec{
+			Hostname:        "stage.example.com",
+			DefaultReplicas: utils.Int32Ptr(2),
+			DefaultAutoscaling: &crd.AutoscalingConfig{
+				MaxReplicas: 6,
+			},
+		},
+	}
+	return frontend, frontendEnvironment
+}
+
+func TestGetAutoscalingConfig(t *testing.T) {
+	frontend, frontendEnvironment := autoscalingTestObjects()
+
+	autoscalingConfig := getAutoscalingConfig(frontend, frontendEnvironment)
+	if autoscalingConfig == nil || autoscalingConfig.MaxReplicas != 6 {
+		t.Fatalf("expected the environment default, got %v", autoscalingConfig)
+	}
+	if minReplicas := getAutoscalingMinReplicas(autoscalingConfig, frontend, frontendEnvironment); *minReplicas != 2 {
+		t.Errorf("expected the minimum to default to the environment replicas, got %d", *minReplicas)
+	}
+
+	frontend.Spec.Autoscaling = &crd.AutoscalingConfig{MinReplicas: utils.Int32Ptr(10), MaxReplicas: 4}
+	autoscalingConfig = getAutoscalingConfig(frontend, frontendEnvironment)
+	if autoscalingConfig.MaxReplicas != 4 {
+		t.Fatalf("expected the frontend autoscaling to replace the default, got %v", autoscalingConfig)
+	}
+	if minReplicas := getAutoscalingMinReplicas(autoscalingConfig, frontend, frontendEnvironment); *minReplicas != 4 {
+		t.Errorf("expected the minimum to be capped at the maximum, got %d", *minReplicas)
+	}
+
+	frontend.Spec.Autoscaling = &crd.AutoscalingConfig{Disabled: true}
+	if autoscalingConfig = getAutoscalingConfig(frontend, frontendEnvironment); autoscalingConfig != nil {
+		t.Errorf("expected a disabled autoscaling to opt out of the default, got %v", autoscalingConfig)
+	}
+}
+
+func TestFrontendAutoscaler(t *testing.T) {
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	frontend.Spec.Autoscaling = &crd.AutoscalingConfig{
+		MaxReplicas:                       8,
+		TargetMemoryUtilizationPercentage: utils.Int32Ptr(70),
+	}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend, frontendEnvironment).Build()
+	nn := types.NamespacedName{Name: "landing-frontend", Namespace: "boot"}
+
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	hpa := &autoscaling.HorizontalPodAutoscaler{}
+	if err := pClient.Get(context.Background(), nn, hpa); err != nil {
+		t.Fatalf("expected the autoscaler to be created: %v", err)
+	}
+	if hpa.Spec.ScaleTargetRef.Kind != "Deployment" || hpa.Spec.ScaleTargetRef.Name != "landing-frontend" {
+		t.Errorf("expected the autoscaler to target the frontend Deployment, got %v", hpa.Spec.ScaleTargetRef)
+	}
+	if *hpa.Spec.MinReplicas != 2 || hpa.Spec.MaxReplicas != 8 {
+		t.Errorf("expected 2-8 replicas, got %d-%d", *hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas)
+	}
+	if len(hpa.Spec.Metrics) != 1 || hpa.Spec.Metrics[0].Resource.Name != v1.ResourceMemory || *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization != 70 {
+		t.Errorf("expected a single memory utilization target, got %v", hpa.Spec.Metrics)
+	}
+	if len(hpa.OwnerReferences) != 1 || hpa.OwnerReferences[0].Name != "landing" {
+		t.Errorf("expected the autoscaler to be owned by the frontend, got %v", hpa.OwnerReferences)
+	}
+
+	// The operator must not reset replicas chosen by the autoscaler
+	d := &apps.Deployment{}
+	if err := pClient.Get(context.Background(), nn, d); err != nil {
+		t.Fatal(err)
+	}
+	d.Spec.Replicas = utils.Int32Ptr(5)
+	if err := pClient.Update(context.Background(), d); err != nil {
+		t.Fatal(err)
+	}
+
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	if err := pClient.Get(context.Background(), nn, d); err != nil {
+		t.Fatal(err)
+	}
+	if *d.Spec.Replicas != 5 {
+		t.Errorf("expected the autoscaled replicas to be kept, got %d", *d.Spec.Replicas)
+	}
+
+	frontend.Spec.Autoscaling = &crd.AutoscalingConfig{Disabled: true}
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	if err := pClient.Get(context.Background(), nn, &autoscaling.HorizontalPodAutoscaler{}); !k8serr.IsNotFound(err) {
+		t.Errorf("expected the autoscaler to be removed, got %v", err)
+	}
+	if err := pClient.Get(context.Background(), nn, d); err != nil {
+		t.Fatal(err)
+	}
+	if *d.Spec.Replicas != 2 {
+		t.Errorf("expected the replicas to be managed by the operator again, got %d", *d.Spec.Replicas)
+	}
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2025 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	apps "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	"github.com/RedHatInsights/frontend-operator/controllers/cdn"
+)
+
+const (
+	// cacheBustMaxAttempts is the first attempt and 5 retries
+	cacheBustMaxAttempts = 6
+
+	// cacheBustRetryInterval is the backoff after the first failed attempt, it doubles
+	// with every further failure
+	cacheBustRetryInterval = 30 * time.Second
+
+	// cacheBustRolloutPollInterval is how often the rollout of a new image is checked, the
+	// Deployment status changes do not trigger a reconciliation
+	cacheBustRolloutPollInterval = 30 * time.Second
+
+	// Resources of the cache bust Job the operator ran before purging the cache itself
+	legacyCacheBustJobSuffix     = "-frontend-cachebust"
+	legacyEdgercConfigMapName    = "akamai-edgerc"
+	defaultCacheBustMessageLimit = 1024
+)
+
+// cacheBustEnabled returns true when the environment purges the cache for the Frontend
+func cacheBustEnabled(frontend *crd.Frontend, frontendEnvironment *crd.FrontendEnvironment) bool {
+	return frontendEnvironment.Spec.EnableAkamaiCacheBust && frontend.Spec.Image != "" && !frontend.Spec.AkamaiCacheBustDisable
+}
+
+// getCachePurgeProvider returns the CDN provider of the environment
+func getCachePurgeProvider(frontendEnvironment *crd.FrontendEnvironment) string {
+	if frontendEnvironment.Spec.CachePurge != nil && frontendEnvironment.Spec.CachePurge.Provider != "" {
+		return frontendEnvironment.Spec.CachePurge.Provider
+	}
+	return crd.CachePurgeAkamai
+}
+
+// getCachePurgeSecretName returns the secret with the credentials of the CDN provider, the
+// HTTP provider does not need one
+func getCachePurgeSecretName(frontendEnvironment *crd.FrontendEnvironment) string {
+	if frontendEnvironment.Spec.CachePurge != nil && frontendEnvironment.Spec.CachePurge.SecretName != "" {
+		return frontendEnvironment.Spec.CachePurge.SecretName
+	}
+	if getCachePurgeProvider(frontendEnvironment) == crd.CachePurgeAkamai {
+		return getAkamaiSecretName(frontendEnvironment)
+	}
+	return ""
+}
+
+// getCachePurgeConfig returns the purge settings of the environment
+func getCachePurgeConfig(frontendEnvironment *crd.FrontendEnvironment) cdn.Config {
+	config := cdn.Config{
+		Provider:      getCachePurgeProvider(frontendEnvironment),
+		AkamaiAction:  frontendEnvironment.Spec.AkamaiCacheBustAction,
+		AkamaiNetwork: frontendEnvironment.Spec.AkamaiCacheBustNetwork,
+	}
+	if cachePurge := frontendEnvironment.Spec.CachePurge; cachePurge != nil {
+		config.FastlyServiceID = cachePurge.FastlyServiceID
+		config.CloudFrontDistributionID = cachePurge.CloudFrontDistributionID
+		config.HTTPMethod = cachePurge.HTTPMethod
+		config.HTTPTagHeader = cachePurge.HTTPTagHeader
+	}
+	return config
+}
+
+// getCacheBustBackoff returns the wait after the given number of failed attempts
+func getCacheBustBackoff(attempts int32) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	return cacheBustRetryInterval << (attempts - 1)
+}
+
+// deploymentRolledOut returns true once every replica of the Deployment runs the image, the
+// cache must not be purged while old pods can still serve the previous assets
+func deploymentRolledOut(d *apps.Deployment, image string) bool {
+	if d.Generation > d.Status.ObservedGeneration {
+		return false
+	}
+	if len(d.Spec.Template.Spec.Containers) == 0 || d.Spec.Template.Spec.Containers[0].Image != image {
+		return false
+	}
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	return d.Status.UpdatedReplicas >= replicas &&
+		d.Status.AvailableReplicas >= replicas &&
+		d.Status.Replicas == d.Status.UpdatedReplicas
+}
+
+// requeueAfter asks the FrontendReconciler to reconcile the Frontend again after d
+func (r *FrontendReconciliation) requeueAfter(d time.Duration) {
+	if r.RequeueAfter == 0 || d < r.RequeueAfter {
+		r.RequeueAfter = d
+	}
+}
+
+func (r *FrontendReconciliation) newPurger(config cdn.Config) (cdn.Purger, error) {
+	if r.NewPurger != nil {
+		return r.NewPurger(config)
+	}
+	return cdn.NewPurger(config)
+}
+
+// purge sends the URLs and cache tags of the status to the CDN provider
+func (r *FrontendReconciliation) purge(status *crd.CacheBustStatus) ([]string, error) {
+	config := getCachePurgeConfig(r.FrontendEnvironment)
+	if secretName := getCachePurgeSecretName(r.FrontendEnvironment); secretName != "" {
+		secret, err := getAkamaiSecret(r.Ctx, r.Client, r.Frontend, secretName)
+		if err != nil {
+			return nil, err
+		}
+		config.Credentials = secret.Data
+	}
+	purger, err := r.newPurger(config)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := purger.Purge(r.Ctx, status.URLs, status.Tags)
+	if result == nil {
+		return nil, err
+	}
+	return result.PurgeIDs, err
+}
+
+// reconcileCacheBust purges the CDN cache once the Deployment runs a new image, or the
+// Frontend changes its rerun token. Failed purges are retried with exponential backoff, the
+// outcome is kept in the Frontend status.
+func (r *FrontendReconciliation) reconcileCacheBust() error {
+	image := r.getStableImage()
+	rerunToken := getRerunToken(r.Frontend)
+	previous := r.Frontend.Status.CacheBust
+	current := previous != nil && previous.Image == image && previous.RerunToken == rerunToken
+	if current && (previous.Phase == crd.CacheBustSucceeded || previous.Phase == crd.CacheBustFailed) {
+		return nil
+	}
+
+	status := previous.DeepCopy()
+	if !current {
+		status = &crd.CacheBustStatus{
+			Image:      image,
+			RerunToken: rerunToken,
+			Phase:      crd.CacheBustPending,
+			URLs:       createCachePurgePathList(r.Frontend, r.FrontendEnvironment),
+			Tags:       r.Frontend.Spec.AkamaiCacheBustTags,
+		}
+	}
+	if len(status.URLs) == 0 && len(status.Tags) == 0 {
+		return nil
+	}
+
+	d := &apps.Deployment{}
+	nn := types.NamespacedName{Name: r.Frontend.Name + "-frontend", Namespace: r.Frontend.Namespace}
+	if err := r.Client.Get(r.Ctx, nn, d); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if !deploymentRolledOut(d, image) {
+		status.Phase = crd.CacheBustPending
+		status.Message = "Waiting for the rollout of the image"
+		r.requeueAfter(cacheBustRolloutPollInterval)
+		return r.setCacheBustStatus(status)
+	}
+
+	if status.LastAttemptTime != nil {
+		if wait := time.Until(status.LastAttemptTime.Add(getCacheBustBackoff(status.Attempts))); wait > 0 {
+			r.requeueAfter(wait)
+			return nil
+		}
+	}
+
+	now := metav1.Now()
+	status.Attempts++
+	status.LastAttemptTime = &now
+	purgeIDs, err := r.purge(status)
+	status.PurgeIDs = purgeIDs
+
+	switch {
+	case err == nil:
+		status.Phase = crd.CacheBustSucceeded
+		status.PurgeTime = &now
+		status.Message = ""
+		r.recordEvent(v1.EventTypeNormal, "CacheBustSucceeded", "Purged %d URLs and %d cache tags from %s", len(status.URLs), len(status.Tags), getCachePurgeProvider(r.FrontendEnvironment))
+	case status.Attempts >= cacheBustMaxAttempts:
+		status.Phase = crd.CacheBustFailed
+		status.Message = truncateMessage(err.Error(), defaultCacheBustMessageLimit)
+		r.recordEvent(v1.EventTypeWarning, "CacheBustFailed", "Cache purge failed after %d attempts: %s", status.Attempts, status.Message)
+	default:
+		backoff := getCacheBustBackoff(status.Attempts)
+		status.Phase = crd.CacheBustRunning
+		status.Message = truncateMessage(err.Error(), defaultCacheBustMessageLimit)
+		r.recordEvent(v1.EventTypeWarning, "CacheBustRetrying", "Cache purge attempt %d of %d failed, retrying in %s: %s", status.Attempts, cacheBustMaxAttempts, backoff, status.Message)
+		r.requeueAfter(backoff)
+	}
+	return r.setCacheBustStatus(status)
+}
+
+func (r *FrontendReconciliation) recordEvent(eventType, reason, messageFmt string, args ...interface{}) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(r.Frontend, eventType, reason, messageFmt, args...)
+}
+
+// setCacheBustStatus persists the status right away, a purge must not be repeated because a
+// later step of the reconciliation failed
+func (r *FrontendReconciliation) setCacheBustStatus(status *crd.CacheBustStatus) error {
+	nn := types.NamespacedName{Name: r.Frontend.Name, Namespace: r.Frontend.Namespace}
+	if err := SetFrontendCacheBustStatus(r.Ctx, r.Client, nn, status); err != nil {
+		return err
+	}
+	r.Frontend.Status.CacheBust = status
+	return nil
+}
+
+// deleteLegacyCacheBustResources removes the cache bust Job and the edgerc ConfigMap, which
+// held the Akamai credentials in plain text, left behind by earlier operator versions
+func (r *FrontendReconciliation) deleteLegacyCacheBustResources() error {
+	j := &batchv1.Job{}
+	nn := types.NamespacedName{Name: r.Frontend.Name + legacyCacheBustJobSuffix, Namespace: r.Frontend.Namespace}
+	if err := r.Client.Get(r.Ctx, nn, j); client.IgnoreNotFound(err) != nil {
+		return err
+	} else if err == nil && isOwnedBy(j, r.Frontend.UID) {
+		backgroundDeletion := metav1.DeletePropagationBackground
+		if err := r.Client.Delete(r.Ctx, j, &client.DeleteOptions{PropagationPolicy: &backgroundDeletion}); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	configMap := &v1.ConfigMap{}
+	nn = types.NamespacedName{Name: legacyEdgercConfigMapName, Namespace: r.Frontend.Namespace}
+	if err := r.Client.Get(r.Ctx, nn, configMap); client.IgnoreNotFound(err) != nil {
+		return err
+	} else if err == nil && isOwnedBy(configMap, r.Frontend.UID) {
+		if err := r.Client.Delete(r.Ctx, configMap); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// truncateMessage keeps the start of a message that is too long for the status, it is cut
+// on a rune boundary as the API server rejects invalid UTF-8
+func truncateMessage(message string, limit int) string {
+	if len(message) <= limit {
+		return message
+	}
+	for limit > 0 && !utf8.RuneStart(message[limit]) {
+		limit--
+	}
+	return fmt.Sprintf("%s...", message[:limit])
+}
@@ -0,0 +1,328 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	"github.com/RedHatInsights/frontend-operator/controllers/cdn"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+	apps "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakePurger struct {
+	urls [][]string
+	tags [][]string
+	err  error
+}
+
+func (p *fakePurger) Purge(_ context.Context, urls []string, tags []string) (*cdn.Result, error) {
+	p.urls = append(p.urls, urls)
+	p.tags = append(p.tags, tags)
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &cdn.Result{PurgeIDs: []string{"url-purge", "tag-purge"}}, nil
+}
+
+func rolledOutDeployment(image string) *apps.Deployment {
+	d := &apps.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "landing-frontend", Namespace: "boot", Generation: 2},
+		Spec:       apps.DeploymentSpec{Replicas: utils.Int32Ptr(2)},
+		Status:     apps.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+	}
+	d.Spec.Template.Spec.Containers = []v1.Container{{Name: "fe-image", Image: image}}
+	return d
+}
+
+func TestDeploymentRolledOut(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		modify func(*apps.Deployment)
+		want   bool
+	}{
+		{name: "rolled out", modify: func(*apps.Deployment) {}, want: true},
+		{name: "spec not observed", modify: func(d *apps.Deployment) { d.Status.ObservedGeneration = 1 }},
+		{name: "other image", modify: func(d *apps.Deployment) { d.Spec.Template.Spec.Containers[0].Image = "quay.io/landing:old" }},
+		{name: "replicas not updated", modify: func(d *apps.Deployment) { d.Status.UpdatedReplicas = 1 }},
+		{name: "replicas not available", modify: func(d *apps.Deployment) { d.Status.AvailableReplicas = 1 }},
+		{name: "old replicas terminating", modify: func(d *apps.Deployment) { d.Status.Replicas = 3 }},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			d := rolledOutDeployment("quay.io/landing:abc")
+			test.modify(d)
+			if got := deploymentRolledOut(d, "quay.io/landing:abc"); got != test.want {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestReconcileCacheBust(t *testing.T) {
+	ctx := context.Background()
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	frontend.Spec.AkamaiCacheBustTags = []string{"landing"}
+	frontendEnvironment.Spec.EnableAkamaiCacheBust = true
+	frontendEnvironment.Spec.AkamaiCacheBustURLs = []string{"console.example.com"}
+	frontendEnvironment.Spec.AkamaiCacheBustNetwork = "staging"
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: AkamaiSecretNameDefault, Namespace: "boot"},
+		Data: map[string][]byte{
+			"host":          []byte("akab-host.luna.akamaiapis.net"),
+			"client_token":  []byte("client"),
+			"client_secret": []byte("secret"),
+			"access_token":  []byte("access"),
+		},
+	}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend, frontendEnvironment, secret).WithStatusSubresource(&crd.Frontend{}).Build()
+	purger := &fakePurger{}
+	var purgeConfig cdn.Config
+	recorder := record.NewFakeRecorder(10)
+	newReconciliation := func() *FrontendReconciliation {
+		return &FrontendReconciliation{
+			Ctx:                 ctx,
+			Client:              pClient,
+			Recorder:            recorder,
+			Frontend:            frontend,
+			FrontendEnvironment: frontendEnvironment,
+			NewPurger: func(config cdn.Config) (cdn.Purger, error) {
+				purgeConfig = config
+				return purger, nil
+			},
+		}
+	}
+	storedStatus := func() *crd.CacheBustStatus {
+		t.Helper()
+		stored := &crd.Frontend{}
+		if err := pClient.Get(ctx, types.NamespacedName{Name: "landing", Namespace: "boot"}, stored); err != nil {
+			t.Fatal(err)
+		}
+		return stored.Status.CacheBust
+	}
+
+	// The cache is not purged before the Deployment runs the image
+	r := newReconciliation()
+	if err := r.reconcileCacheBust(); err != nil {
+		t.Fatal(err)
+	}
+	if status := storedStatus(); status == nil || status.Phase != crd.CacheBustPending || len(status.URLs) != 1 {
+		t.Fatalf("expected a pending purge, got %+v", status)
+	}
+	if len(purger.urls) != 0 || r.RequeueAfter != cacheBustRolloutPollInterval {
+		t.Fatalf("expected the rollout to be polled, got %d purges and requeue after %s", len(purger.urls), r.RequeueAfter)
+	}
+
+	if err := pClient.Create(ctx, rolledOutDeployment("quay.io/landing:abc")); err != nil {
+		t.Fatal(err)
+	}
+	if err := newReconciliation().reconcileCacheBust(); err != nil {
+		t.Fatal(err)
+	}
+	status := storedStatus()
+	if status.Phase != crd.CacheBustSucceeded || status.Attempts != 1 || status.PurgeTime == nil || len(status.PurgeIDs) != 2 {
+		t.Fatalf("expected the purge to succeed, got %+v", status)
+	}
+	if len(purger.urls) != 1 || purger.urls[0][0] != "https://console.example.com/apps/landing/fed-mods.json" || purger.tags[0][0] != "landing" {
+		t.Fatalf("expected the URLs and the tags to be purged, got %v and %v", purger.urls, purger.tags)
+	}
+	if purgeConfig.Provider != cdn.ProviderAkamai || purgeConfig.AkamaiNetwork != "staging" || string(purgeConfig.Credentials["client_token"]) != "client" {
+		t.Errorf("unexpected purge config %+v", purgeConfig)
+	}
+	if event := <-recorder.Events; !strings.HasPrefix(event, "Normal CacheBustSucceeded") {
+		t.Errorf("unexpected event %q", event)
+	}
+
+	// A purged image is not purged again
+	if err := newReconciliation().reconcileCacheBust(); err != nil {
+		t.Fatal(err)
+	}
+	if len(purger.urls) != 1 {
+		t.Fatalf("expected no further purge, got %d", len(purger.urls))
+	}
+
+	// Failed purges of a new image are retried with backoff
+	frontend.Spec.Image = "quay.io/landing:def"
+	d := &apps.Deployment{}
+	if err := pClient.Get(ctx, types.NamespacedName{Name: "landing-frontend", Namespace: "boot"}, d); err != nil {
+		t.Fatal(err)
+	}
+	d.Spec.Template.Spec.Containers[0].Image = frontend.Spec.Image
+	if err := pClient.Update(ctx, d); err != nil {
+		t.Fatal(err)
+	}
+	purger.urls = nil
+	purger.err = errors.New("delete url purge failed with 403: The signature does not match")
+
+	r = newReconciliation()
+	if err := r.reconcileCacheBust(); err != nil {
+		t.Fatal(err)
+	}
+	status = storedStatus()
+	if status.Image != frontend.Spec.Image || status.Phase != crd.CacheBustRunning || status.Attempts != 1 || !strings.Contains(status.Message, "403") {
+		t.Fatalf("expected the purge to be retried, got %+v", status)
+	}
+	if r.RequeueAfter != cacheBustRetryInterval {
+		t.Errorf("expected a retry after %s, got %s", cacheBustRetryInterval, r.RequeueAfter)
+	}
+	if event := <-recorder.Events; !strings.HasPrefix(event, "Warning CacheBustRetrying") {
+		t.Errorf("unexpected event %q", event)
+	}
+
+	if err := newReconciliation().reconcileCacheBust(); err != nil {
+		t.Fatal(err)
+	}
+	if len(purger.urls) != 1 {
+		t.Fatalf("expected no attempt during the backoff, got %d", len(purger.urls))
+	}
+
+	// The last attempt fails the purge
+	lastAttemptTime := metav1.NewTime(time.Now().Add(-time.Hour))
+	frontend.Status.CacheBust.Attempts = cacheBustMaxAttempts - 1
+	frontend.Status.CacheBust.LastAttemptTime = &lastAttemptTime
+	if err := newReconciliation().reconcileCacheBust(); err != nil {
+		t.Fatal(err)
+	}
+	if status = storedStatus(); status.Phase != crd.CacheBustFailed || status.Attempts != cacheBustMaxAttempts {
+		t.Fatalf("expected the purge to fail, got %+v", status)
+	}
+	if event := <-recorder.Events; !strings.HasPrefix(event, "Warning CacheBustFailed") {
+		t.Errorf("unexpected event %q", event)
+	}
+
+	// A new rerun token purges the current image again
+	purger.err = nil
+	frontend.Spec.Jobs = &crd.FrontendJobsConfig{RerunToken: "2025-03-01T10:00:00Z"}
+	if err := newReconciliation().reconcileCacheBust(); err != nil {
+		t.Fatal(err)
+	}
+	if status = storedStatus(); status.Phase != crd.CacheBustSucceeded || status.Attempts != 1 || status.RerunToken != "2025-03-01T10:00:00Z" {
+		t.Fatalf("expected the rerun to purge the cache, got %+v", status)
+	}
+	if event := <-recorder.Events; !strings.HasPrefix(event, "Normal CacheBustSucceeded") {
+		t.Errorf("unexpected event %q", event)
+	}
+}
+
+func TestTruncateMessage(t *testing.T) {
+	if message := truncateMessage("purge failed", 20); message != "purge failed" {
+		t.Errorf("expected the short message to be kept, got %q", message)
+	}
+	if message := truncateMessage("purge failed", 5); message != "purge..." {
+		t.Errorf("expected the message to be truncated, got %q", message)
+	}
+	// "ü" is two bytes, the limit falls inside it
+	message := truncateMessage("purge für", 8)
+	if !utf8.ValidString(message) || message != "purge f..." {
+		t.Errorf("expected the message to be truncated before the rune, got %q", message)
+	}
+}
+
+func TestGetCachePurgeConfig(t *testing.T) {
+	_, frontendEnvironment := autoscalingTestObjects()
+	frontendEnvironment.Spec.AkamaiSecretName = "akamai-stage"
+	if provider, secretName := getCachePurgeProvider(frontendEnvironment), getCachePurgeSecretName(frontendEnvironment); provider != crd.CachePurgeAkamai || secretName != "akamai-stage" {
+		t.Errorf("expected Akamai with the akamai secret by default, got %s with %q", provider, secretName)
+	}
+
+	frontendEnvironment.Spec.CachePurge = &crd.CachePurgeConfig{Provider: crd.CachePurgeHTTP, HTTPTagHeader: "xkey"}
+	if secretName := getCachePurgeSecretName(frontendEnvironment); secretName != "" {
+		t.Errorf("expected the HTTP provider to need no secret, got %q", secretName)
+	}
+	if config := getCachePurgeConfig(frontendEnvironment); config.Provider != cdn.ProviderHTTP || config.HTTPTagHeader != "xkey" {
+		t.Errorf("unexpected config %+v", config)
+	}
+
+	frontendEnvironment.Spec.CachePurge = &crd.CachePurgeConfig{Provider: crd.CachePurgeFastly, SecretName: "fastly", FastlyServiceID: "SU1Z0isxPaozGVKXdv0eY"}
+	if config, secretName := getCachePurgeConfig(frontendEnvironment), getCachePurgeSecretName(frontendEnvironment); config.FastlyServiceID != "SU1Z0isxPaozGVKXdv0eY" || secretName != "fastly" {
+		t.Errorf("unexpected config %+v with secret %q", config, secretName)
+	}
+}
+
+func TestDeleteLegacyCacheBustResources(t *testing.T) {
+	ctx := context.Background()
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	ownerReferences := []metav1.OwnerReference{frontend.MakeOwnerReference()}
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "landing-frontend-cachebust", Namespace: "boot", OwnerReferences: ownerReferences}}
+	configMap := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "akamai-edgerc", Namespace: "boot", OwnerReferences: ownerReferences}}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend, frontendEnvironment, job, configMap).Build()
+
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	for _, obj := range []client.Object{&batchv1.Job{}, &v1.ConfigMap{}} {
+		name := "landing-frontend-cachebust"
+		if _, ok := obj.(*v1.ConfigMap); ok {
+			name = "akamai-edgerc"
+		}
+		if err := pClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "boot"}, obj); !k8serr.IsNotFound(err) {
+			t.Errorf("expected %s to be deleted, got %v", name, err)
+		}
+	}
+}
+
+// The credentials are read from the Secret for every purge, they are never copied into
+// another object and a rotated Secret is used by the next purge
+func TestCacheBustCredentialsRotation(t *testing.T) {
+	ctx := context.Background()
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	frontendEnvironment.Spec.EnableAkamaiCacheBust = true
+	frontendEnvironment.Spec.AkamaiCacheBustURLs = []string{"console.example.com"}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: AkamaiSecretNameDefault, Namespace: "boot"},
+		Data:       map[string][]byte{"client_secret": []byte("first")},
+	}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend, frontendEnvironment, secret, rolledOutDeployment("quay.io/landing:abc")).WithStatusSubresource(&crd.Frontend{}).Build()
+	clientSecrets := []string{}
+	reconciliation := &FrontendReconciliation{
+		Ctx:                 ctx,
+		Client:              pClient,
+		Frontend:            frontend,
+		FrontendEnvironment: frontendEnvironment,
+		NewPurger: func(config cdn.Config) (cdn.Purger, error) {
+			clientSecrets = append(clientSecrets, string(config.Credentials["client_secret"]))
+			return &fakePurger{}, nil
+		},
+	}
+
+	if err := reconciliation.reconcileCacheBust(); err != nil {
+		t.Fatal(err)
+	}
+
+	secret.Data["client_secret"] = []byte("rotated")
+	if err := pClient.Update(ctx, secret); err != nil {
+		t.Fatal(err)
+	}
+	frontend.Spec.Image = "quay.io/landing:def"
+	if err := pClient.Update(ctx, rolledOutDeployment(frontend.Spec.Image)); err != nil {
+		t.Fatal(err)
+	}
+	if err := reconciliation.reconcileCacheBust(); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Join(clientSecrets, ",") != "first,rotated" {
+		t.Errorf("expected the rotated credentials to be used, got %v", clientSecrets)
+	}
+	configMaps := &v1.ConfigMapList{}
+	if err := pClient.List(ctx, configMaps, client.InNamespace("boot")); err != nil {
+		t.Fatal(err)
+	}
+	secrets := &v1.SecretList{}
+	if err := pClient.List(ctx, secrets, client.InNamespace("boot")); err != nil {
+		t.Fatal(err)
+	}
+	if len(configMaps.Items) != 0 || len(secrets.Items) != 1 {
+		t.Errorf("expected the credentials not to be copied, got %d config maps and %d secrets", len(configMaps.Items), len(secrets.Items))
+	}
+}
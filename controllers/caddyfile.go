@@ -0,0 +1,188 @@
+/*
+Copyright 2025 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	v1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+)
+
+//go:embed templates/Caddyfile
+var caddyFileTemplate string
+
+var caddyfileTemplate = template.Must(template.New("Caddyfile").Funcs(template.FuncMap{
+	"indent": func(spaces int, text string) string {
+		pad := strings.Repeat(" ", spaces)
+		lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+		for i, line := range lines {
+			if line != "" {
+				lines[i] = pad + line
+			}
+		}
+		return strings.Join(lines, "\n")
+	},
+}).Parse(caddyFileTemplate))
+
+// Asset roots of the frontend images
+const (
+	stableAssetRoot  = "/opt/app-root/src/dist/stable"
+	previewAssetRoot = "/opt/app-root/src/dist/preview"
+)
+
+// caddyfileRoute serves an asset root under a path prefix
+type caddyfileRoute struct {
+	// Name of the request matcher
+	Name        string
+	Description string
+	Prefix      string
+	Root        string
+}
+
+// caddyfileConfig is the input of the Caddyfile template
+type caddyfileConfig struct {
+	Routes      []caddyfileRoute
+	Redirects   []crd.CaddyRedirect
+	Handles     []crd.CaddyHandle
+	SPAFallback bool
+	Compression bool
+}
+
+// defaultCaddyRedirects send the environment entry points to chrome
+var defaultCaddyRedirects = []crd.CaddyRedirect{
+	{From: "/beta/", To: "/beta/apps/chrome/index.html", Permanent: true},
+	{From: "/preview/", To: "/preview/apps/chrome/index.html", Permanent: true},
+	{From: "/", To: "/apps/chrome/index.html", Permanent: true},
+}
+
+// newCaddyfileRoutes returns the stable, beta and preview routes of a frontend path
+func newCaddyfileRoutes(routePath, betaRoutePath, previewRoutePath string) []caddyfileRoute {
+	return []caddyfileRoute{
+		{Name: "app", Description: "main app", Prefix: routePath, Root: stableAssetRoot},
+		{Name: "beta", Description: "beta app", Prefix: betaRoutePath, Root: previewAssetRoot},
+		{Name: "preview", Description: "preview app", Prefix: previewRoutePath, Root: previewAssetRoot},
+	}
+}
+
+// renderCaddyfile executes the Caddyfile template
+func renderCaddyfile(config caddyfileConfig) (string, error) {
+	var caddyfile bytes.Buffer
+	if err := caddyfileTemplate.Execute(&caddyfile, config); err != nil {
+		return "", fmt.Errorf("error rendering Caddyfile: %w", err)
+	}
+	return caddyfile.String(), nil
+}
+
+// defaultCaddyfile is shared by the frontends of an environment, the route paths are taken
+// from the environment of the frontend container
+var defaultCaddyfile = func() string {
+	caddyfile, err := renderCaddyfile(caddyfileConfig{
+		Routes:    newCaddyfileRoutes("{$ROUTE_PATH}", "{$BETA_ROUTE_PATH}", "{$PREVIEW_ROUTE_PATH}"),
+		Redirects: defaultCaddyRedirects,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return caddyfile
+}()
+
+// getFrontendAssetsPrefix returns the first path segment the assets of the frontend are
+// served under
+func getFrontendAssetsPrefix(frontend *crd.Frontend) string {
+	if frontend.Spec.AssetsPrefix != "" {
+		return frontend.Spec.AssetsPrefix
+	}
+	return "apps"
+}
+
+// getFrontendRoutePath returns the path the assets of the frontend are served under
+func getFrontendRoutePath(frontend *crd.Frontend) string {
+	return fmt.Sprintf("/%s/%s", getFrontendAssetsPrefix(frontend), frontend.Name)
+}
+
+// usesFrontendCaddyfile returns true when the frontend container gets its own Caddyfile
+// instead of the one shared by the environment
+func usesFrontendCaddyfile(frontend *crd.Frontend, frontendEnvironment *crd.FrontendEnvironment) bool {
+	return frontendEnvironment.Spec.OverwriteCaddyConfig && frontend.Name != "chrome" && frontend.Spec.Caddy != nil
+}
+
+// getCaddyfileKey returns the key of the environment config map holding the Caddyfile of the
+// frontend. The config map is copied to every namespace, so the key includes the namespace.
+func getCaddyfileKey(frontend *crd.Frontend, frontendEnvironment *crd.FrontendEnvironment) string {
+	if !usesFrontendCaddyfile(frontend, frontendEnvironment) {
+		return "Caddyfile"
+	}
+	return fmt.Sprintf("Caddyfile.%s.%s", frontend.Namespace, frontend.Name)
+}
+
+// errUnbalancedCaddyBraces is returned for handle directives that would break the blocks of
+// the rendered Caddyfile
+var errUnbalancedCaddyBraces = errors.New("directives have unbalanced braces")
+
+// getMountedCaddyfileKey returns the key the caddy volume of the frontend mounts. A volume item
+// with a missing key keeps the pods from starting, so the Caddyfile of the frontend is only
+// mounted once the environment config map holds it. It is missing until the config of the
+// environment is generated and when its handles were skipped, the shared Caddyfile is mounted
+// until then.
+func (r *FrontendReconciliation) getMountedCaddyfileKey() (string, error) {
+	key := getCaddyfileKey(r.Frontend, r.FrontendEnvironment)
+	if key == "Caddyfile" {
+		return key, nil
+	}
+
+	cfgMap := &v1.ConfigMap{}
+	err := r.Client.Get(r.Ctx, types.NamespacedName{Name: r.Frontend.Spec.EnvName, Namespace: r.Frontend.Namespace}, cfgMap)
+	if err != nil && !k8serr.IsNotFound(err) {
+		return "", err
+	}
+	if _, ok := cfgMap.Data[key]; !ok {
+		return "Caddyfile", nil
+	}
+	return key, nil
+}
+
+// renderFrontendCaddyfile renders the Caddyfile of a frontend with custom routing, the
+// redirects of the frontend take precedence over the default ones. The directives of the
+// handles are checked again, a Frontend stored before the webhook ran could break the site
+// block of the Caddyfile.
+func renderFrontendCaddyfile(frontend *crd.Frontend) (string, error) {
+	routePath := getFrontendRoutePath(frontend)
+	caddy := frontend.Spec.Caddy
+
+	for _, handle := range caddy.Handles {
+		if !crd.BalancedBraces(handle.Directives) {
+			return "", fmt.Errorf("handle %s: %w", handle.Path, errUnbalancedCaddyBraces)
+		}
+	}
+
+	return renderCaddyfile(caddyfileConfig{
+		Routes:      newCaddyfileRoutes(routePath, "/beta"+routePath, "/preview"+routePath),
+		Redirects:   append(append([]crd.CaddyRedirect{}, caddy.Redirects...), defaultCaddyRedirects...),
+		Handles:     caddy.Handles,
+		SPAFallback: caddy.SPAFallback,
+		Compression: caddy.Compression,
+	})
+}
@@ -0,0 +1,187 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDefaultCaddyfile(t *testing.T) {
+	for _, expected := range []string{
+		"@app_match {\n        path {$ROUTE_PATH}*\n    }",
+		"uri strip_prefix {$BETA_ROUTE_PATH}",
+		"root /opt/app-root/src/dist/preview",
+		"handle / {\n        redir /apps/chrome/index.html permanent\n    }",
+	} {
+		if !strings.Contains(defaultCaddyfile, expected) {
+			t.Errorf("expected the default Caddyfile to contain %q", expected)
+		}
+	}
+	if strings.Contains(defaultCaddyfile, "encode") || strings.Contains(defaultCaddyfile, "try_files") {
+		t.Error("expected the default Caddyfile without compression or SPA fallback")
+	}
+}
+
+func TestRenderFrontendCaddyfile(t *testing.T) {
+	frontend, _ := autoscalingTestObjects()
+	frontend.Spec.AssetsPrefix = "mfe"
+	frontend.Spec.Caddy = &crd.CaddyConfig{
+		SPAFallback: true,
+		Compression: true,
+		Redirects:   []crd.CaddyRedirect{{From: "/landing", To: "/mfe/landing/"}},
+		Handles:     []crd.CaddyHandle{{Path: "/mfe/landing/api*", Directives: "reverse_proxy landing-api:8000"}},
+	}
+
+	caddyfile, err := renderFrontendCaddyfile(frontend)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"    encode zstd gzip\n",
+		"    handle /mfe/landing/api* {\n        reverse_proxy landing-api:8000\n    }",
+		"path /mfe/landing*",
+		"uri strip_prefix /beta/mfe/landing",
+		"uri strip_prefix /preview/mfe/landing",
+		"try_files {path} {path}/ /index.html",
+		"handle /landing {\n        redir /mfe/landing/\n    }",
+	} {
+		if !strings.Contains(caddyfile, expected) {
+			t.Errorf("expected the Caddyfile to contain %q:\n%s", expected, caddyfile)
+		}
+	}
+	if strings.Index(caddyfile, "handle /landing {") > strings.Index(caddyfile, "handle / {") {
+		t.Error("expected the frontend redirects before the default redirects")
+	}
+	if len(frontend.Spec.Caddy.Redirects) != 1 {
+		t.Error("expected the frontend redirects not to be modified")
+	}
+}
+
+func TestRenderConfigDataFrontendCaddyfile(t *testing.T) {
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	frontend.Spec.Caddy = &crd.CaddyConfig{SPAFallback: true}
+	feList := &crd.FrontendList{Items: []crd.Frontend{*frontend}}
+
+	data, err := renderConfigData(frontendEnvironment, feList, &RenderedConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := data["Caddyfile.boot.landing"]; ok {
+		t.Error("expected no frontend Caddyfile without overwriteCaddyConfig")
+	}
+
+	frontendEnvironment.Spec.OverwriteCaddyConfig = true
+	data, err = renderConfigData(frontendEnvironment, feList, &RenderedConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data["Caddyfile"] != defaultCaddyfile {
+		t.Error("expected the shared Caddyfile to be kept")
+	}
+	if !strings.Contains(data["Caddyfile.boot.landing"], "try_files") {
+		t.Errorf("expected the frontend Caddyfile, got %q", data["Caddyfile.boot.landing"])
+	}
+}
+
+func TestRenderConfigDataSkipsUnbalancedCaddyfile(t *testing.T) {
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	frontendEnvironment.Spec.OverwriteCaddyConfig = true
+	frontend.Spec.Caddy = &crd.CaddyConfig{
+		Handles: []crd.CaddyHandle{{Path: "/apps/landing/api*", Directives: "route {\n    reverse_proxy landing-api:8000\n"}},
+	}
+	other := frontend.DeepCopy()
+	other.Name = "inventory"
+	other.Spec.Caddy = &crd.CaddyConfig{SPAFallback: true}
+	feList := &crd.FrontendList{Items: []crd.Frontend{*frontend, *other}}
+
+	rendered := &RenderedConfig{}
+	data, err := renderConfigData(frontendEnvironment, feList, rendered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := data["Caddyfile.boot.landing"]; ok {
+		t.Error("expected no Caddyfile for the frontend with unbalanced braces")
+	}
+	if _, ok := data["Caddyfile.boot.inventory"]; !ok {
+		t.Error("expected the Caddyfile of the other frontend to be kept")
+	}
+	expected := []string{"boot/landing: Caddyfile Caddyfile.boot.landing: handle /apps/landing/api*: directives have unbalanced braces"}
+	if warnings := rendered.Warnings(); len(warnings) != 1 || warnings[0] != expected[0] {
+		t.Errorf("expected %v, got %v", expected, warnings)
+	}
+}
+
+func TestFrontendCaddyfileVolume(t *testing.T) {
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	frontendEnvironment.Spec.OverwriteCaddyConfig = true
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend, frontendEnvironment).Build()
+	nn := types.NamespacedName{Name: "landing-frontend", Namespace: "boot"}
+
+	caddyfileKey := func() string {
+		d := &apps.Deployment{}
+		if err := pClient.Get(context.Background(), nn, d); err != nil {
+			t.Fatal(err)
+		}
+		for _, volume := range d.Spec.Template.Spec.Volumes {
+			if volume.Name == "caddy" {
+				return volume.ConfigMap.Items[0].Key
+			}
+		}
+		t.Fatal("expected the caddy volume")
+		return ""
+	}
+
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	if key := caddyfileKey(); key != "Caddyfile" {
+		t.Errorf("expected the shared Caddyfile, got %s", key)
+	}
+
+	// The Caddyfile of the frontend is only mounted once the environment config holds it
+	frontend.Spec.Caddy = &crd.CaddyConfig{Compression: true}
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	if key := caddyfileKey(); key != "Caddyfile" {
+		t.Errorf("expected the shared Caddyfile before the config is generated, got %s", key)
+	}
+
+	writeEnvironmentConfig := func() {
+		data, err := renderConfigData(frontendEnvironment, &crd.FrontendList{Items: []crd.Frontend{*frontend}}, &RenderedConfig{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfgMap := &v1.ConfigMap{}
+		err = pClient.Get(context.Background(), types.NamespacedName{Name: "stage", Namespace: "boot"}, cfgMap)
+		cfgMap.Data = data
+		if k8serr.IsNotFound(err) {
+			cfgMap.ObjectMeta = metav1.ObjectMeta{Name: "stage", Namespace: "boot"}
+			err = pClient.Create(context.Background(), cfgMap)
+		} else if err == nil {
+			err = pClient.Update(context.Background(), cfgMap)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeEnvironmentConfig()
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	if key := caddyfileKey(); key != "Caddyfile.boot.landing" {
+		t.Errorf("expected the frontend Caddyfile, got %s", key)
+	}
+
+	// A frontend whose Caddyfile is skipped falls back to the shared one
+	frontend.Spec.Caddy.Handles = []crd.CaddyHandle{{Path: "/apps/landing/api*", Directives: "route {"}}
+	writeEnvironmentConfig()
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	if key := caddyfileKey(); key != "Caddyfile" {
+		t.Errorf("expected the shared Caddyfile for the skipped frontend, got %s", key)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cdn
+
+import (
+	"context"
+
+	"github.com/RedHatInsights/frontend-operator/controllers/akamai"
+)
+
+// akamaiPurger purges with the Akamai Fast Purge API
+type akamaiPurger struct {
+	client  *akamai.Client
+	action  string
+	network string
+}
+
+func newAkamaiPurger(config Config) (*akamaiPurger, error) {
+	credentials, err := akamai.CredentialsFromSecret(config.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	p := &akamaiPurger{
+		client:  akamai.NewClient(credentials),
+		action:  config.AkamaiAction,
+		network: config.AkamaiNetwork,
+	}
+	if p.action == "" {
+		p.action = akamai.ActionDelete
+	}
+	if p.network == "" {
+		p.network = akamai.NetworkProduction
+	}
+	return p, nil
+}
+
+func (p *akamaiPurger) Purge(ctx context.Context, urls []string, tags []string) (*Result, error) {
+	result := &Result{}
+	for _, request := range []akamai.PurgeRequest{
+		{Type: akamai.TypeURL, Objects: urls},
+		{Type: akamai.TypeTag, Objects: tags},
+	} {
+		if len(request.Objects) == 0 {
+			continue
+		}
+		request.Action = p.action
+		request.Network = p.network
+		purgeResult, err := p.client.Purge(ctx, request)
+		if purgeResult != nil {
+			result.PurgeIDs = append(result.PurgeIDs, purgeResult.PurgeIDs...)
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cdn purges the frontend assets from the CDN in front of the frontends, with one
+// Purger per supported provider
+package cdn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Providers the cache can be purged from, the names match the FrontendEnvironment API
+const (
+	ProviderAkamai     = "Akamai"
+	ProviderFastly     = "Fastly"
+	ProviderCloudFront = "CloudFront"
+	ProviderHTTP       = "HTTP"
+)
+
+// requestTimeout bounds every request made to a provider
+const requestTimeout = 30 * time.Second
+
+// Result of a purge
+type Result struct {
+	// IDs the provider assigned to the accepted purge requests, if any
+	PurgeIDs []string
+}
+
+// Purger purges URLs and cache tags from a CDN, tests replace it with a stand-in
+type Purger interface {
+	Purge(ctx context.Context, urls []string, tags []string) (*Result, error)
+}
+
+// Config selects the provider and holds its settings
+type Config struct {
+	Provider string
+	// Data of the secret with the credentials of the provider
+	Credentials map[string][]byte
+
+	AkamaiAction             string
+	AkamaiNetwork            string
+	FastlyServiceID          string
+	CloudFrontDistributionID string
+	HTTPMethod               string
+	HTTPTagHeader            string
+}
+
+// NewPurger returns the Purger of the configured provider
+func NewPurger(config Config) (Purger, error) {
+	switch config.Provider {
+	case ProviderAkamai, "":
+		return newAkamaiPurger(config)
+	case ProviderFastly:
+		return newFastlyPurger(config)
+	case ProviderCloudFront:
+		return newCloudFrontPurger(config)
+	case ProviderHTTP:
+		return newHTTPPurger(config), nil
+	default:
+		return nil, fmt.Errorf("unknown cache purge provider %q", config.Provider)
+	}
+}
+
+// requireKeys returns the trimmed values of the secret keys, or an error naming the keys
+// that are missing
+func requireKeys(provider string, data map[string][]byte, keys ...string) (map[string]string, error) {
+	values := map[string]string{}
+	missing := []string{}
+	for _, key := range keys {
+		value := strings.TrimSpace(string(data[key]))
+		if value == "" {
+			missing = append(missing, key)
+		}
+		values[key] = value
+	}
+	if len(missing) != 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("%s credentials are missing %s", strings.ToLower(provider), strings.Join(missing, ", "))
+	}
+	return values, nil
+}
+
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: requestTimeout}
+}
@@ -0,0 +1,183 @@
+package cdn
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// The example request of the Signature Version 4 documentation
+func TestSignV4(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	credentials := awsCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+
+	credentials.sign(req, nil, "us-east-1", "iam", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	expected := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"
+	if authorization := req.Header.Get("Authorization"); authorization != expected {
+		t.Errorf("unexpected authorization %s", authorization)
+	}
+}
+
+func TestNewPurger(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		config Config
+		err    string
+	}{
+		{name: "akamai credentials", config: Config{}, err: "akamai credentials are missing access_token, client_secret, client_token, host"},
+		{name: "fastly credentials", config: Config{Provider: ProviderFastly}, err: "fastly credentials are missing api_token"},
+		{name: "cloudfront distribution", config: Config{Provider: ProviderCloudFront}, err: "cloudfront purges need the cloudFrontDistributionID of the environment"},
+		{name: "unknown provider", config: Config{Provider: "Varnish"}, err: `unknown cache purge provider "Varnish"`},
+		{name: "http without credentials", config: Config{Provider: ProviderHTTP}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewPurger(test.config)
+			if test.err == "" && err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+			if test.err != "" && (err == nil || err.Error() != test.err) {
+				t.Errorf("expected %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestFastlyPurge(t *testing.T) {
+	var mu sync.Mutex
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Fastly-Key") != "token" {
+			t.Errorf("unexpected request %s with key %q", r.Method, r.Header.Get("Fastly-Key"))
+		}
+		mu.Lock()
+		requests = append(requests, r.URL.Path+" "+r.Header.Get("Surrogate-Key"))
+		mu.Unlock()
+		if strings.HasPrefix(r.URL.Path, "/service/") {
+			_, _ = w.Write([]byte(`{"landing":"108-2","chrome":"108-3"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"ok","id":"108-1"}`))
+	}))
+	defer server.Close()
+
+	purger, err := newFastlyPurger(Config{Provider: ProviderFastly, Credentials: map[string][]byte{"api_token": []byte("token")}, FastlyServiceID: "SU1Z0isxPaozGVKXdv0eY"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	purger.BaseURL = server.URL
+
+	result, err := purger.Purge(context.Background(), []string{"https://console.example.com/apps/landing/fed-mods.json"}, []string{"landing", "chrome"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 2 || requests[0] != "/purge/console.example.com/apps/landing/fed-mods.json " || requests[1] != "/service/SU1Z0isxPaozGVKXdv0eY/purge landing chrome" {
+		t.Errorf("unexpected requests %q", requests)
+	}
+	if strings.Join(result.PurgeIDs, ",") != "108-1,108-2,108-3" {
+		t.Errorf("unexpected purge IDs %v", result.PurgeIDs)
+	}
+
+	purger.serviceID = ""
+	if _, err := purger.Purge(context.Background(), nil, []string{"landing"}); err == nil {
+		t.Error("expected the cache tags to need a service")
+	}
+}
+
+func TestCloudFrontPurge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2020-05-31/distribution/E2QWRUHEXAMPLE/invalidation" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20250301/us-east-1/cloudfront/aws4_request, SignedHeaders=content-type;host;x-amz-date;x-amz-security-token,") {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		batch := cloudFrontInvalidationBatch{}
+		if err := xml.Unmarshal(body, &batch); err != nil {
+			t.Error(err)
+		}
+		if batch.Paths.Quantity != 2 || strings.Join(batch.Paths.Items, ",") != "/apps/landing/fed-mods.json,/" {
+			t.Errorf("unexpected paths %+v", batch.Paths)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><Invalidation xmlns="http://cloudfront.amazonaws.com/doc/2020-05-31/"><Id>I2J0I21PCUYOIK</Id><Status>InProgress</Status></Invalidation>`))
+	}))
+	defer server.Close()
+
+	purger, err := newCloudFrontPurger(Config{
+		Provider:                 ProviderCloudFront,
+		CloudFrontDistributionID: "E2QWRUHEXAMPLE",
+		Credentials: map[string][]byte{
+			"aws_access_key_id":     []byte("AKIDEXAMPLE"),
+			"aws_secret_access_key": []byte("secret"),
+			"aws_session_token":     []byte("session"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	purger.BaseURL = server.URL
+	purger.now = func() time.Time { return time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC) }
+
+	result, err := purger.Purge(context.Background(), []string{
+		"https://console.example.com/apps/landing/fed-mods.json",
+		"https://stage.example.com/apps/landing/fed-mods.json",
+		"https://app.company.com",
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.PurgeIDs) != 1 || result.PurgeIDs[0] != "I2J0I21PCUYOIK" {
+		t.Errorf("unexpected purge IDs %v", result.PurgeIDs)
+	}
+
+	if _, err := purger.Purge(context.Background(), nil, []string{"landing"}); err == nil {
+		t.Error("expected cache tags to be rejected")
+	}
+}
+
+func TestHTTPPurge(t *testing.T) {
+	var mu sync.Mutex
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.Header.Get("Xkey"))
+		mu.Unlock()
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer server.Close()
+
+	purger, err := NewPurger(Config{Provider: ProviderHTTP, HTTPTagHeader: "xkey", Credentials: map[string][]byte{"token": []byte("token\n")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := purger.Purge(context.Background(), []string{server.URL + "/apps/landing/fed-mods.json", server.URL + "/apps/landing/index.html"}, []string{"landing", "chrome"}); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"PURGE /apps/landing/fed-mods.json ", "PURGE /apps/landing/index.html ", "PURGE / landing chrome"}
+	if strings.Join(requests, "|") != strings.Join(expected, "|") {
+		t.Errorf("unexpected requests %q", requests)
+	}
+
+	_, err = purger.Purge(context.Background(), []string{server.URL + "/missing"}, nil)
+	if err == nil || !strings.HasSuffix(err.Error(), "failed with 405") {
+		t.Errorf("expected the rejected purge to fail, got %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2025 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cdn
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// maxCloudFrontPaths is the number of paths sent in one invalidation, CloudFront limits the
+// paths of the invalidations in progress to 3000
+const maxCloudFrontPaths = 1000
+
+// The CloudFront API is global, its requests are signed for us-east-1
+const (
+	cloudFrontRegion  = "us-east-1"
+	cloudFrontService = "cloudfront"
+)
+
+// cloudFrontPurger creates CloudFront invalidations for the paths of the URLs
+type cloudFrontPurger struct {
+	// BaseURL of the API, tests point it at a stand-in
+	BaseURL    string
+	HTTPClient *http.Client
+
+	credentials    awsCredentials
+	distributionID string
+	now            func() time.Time
+}
+
+func newCloudFrontPurger(config Config) (*cloudFrontPurger, error) {
+	if config.CloudFrontDistributionID == "" {
+		return nil, fmt.Errorf("cloudfront purges need the cloudFrontDistributionID of the environment")
+	}
+	values, err := requireKeys(ProviderCloudFront, config.Credentials, "aws_access_key_id", "aws_secret_access_key")
+	if err != nil {
+		return nil, err
+	}
+	return &cloudFrontPurger{
+		BaseURL:    "https://cloudfront.amazonaws.com",
+		HTTPClient: newHTTPClient(),
+		credentials: awsCredentials{
+			AccessKeyID:     values["aws_access_key_id"],
+			SecretAccessKey: values["aws_secret_access_key"],
+			SessionToken:    string(bytes.TrimSpace(config.Credentials["aws_session_token"])),
+		},
+		distributionID: config.CloudFrontDistributionID,
+		now:            time.Now,
+	}, nil
+}
+
+type cloudFrontPaths struct {
+	Quantity int      `xml:"Quantity"`
+	Items    []string `xml:"Items>Path"`
+}
+
+type cloudFrontInvalidationBatch struct {
+	XMLName         xml.Name        `xml:"http://cloudfront.amazonaws.com/doc/2020-05-31/ InvalidationBatch"`
+	CallerReference string          `xml:"CallerReference"`
+	Paths           cloudFrontPaths `xml:"Paths"`
+}
+
+type cloudFrontInvalidation struct {
+	ID string `xml:"Id"`
+}
+
+type cloudFrontError struct {
+	Code    string `xml:"Error>Code"`
+	Message string `xml:"Error>Message"`
+}
+
+// cloudFrontPathsOf returns the distinct paths of the URLs, CloudFront invalidates paths of
+// the distribution rather than URLs
+func cloudFrontPathsOf(urls []string) ([]string, error) {
+	paths := []string{}
+	seen := map[string]bool{}
+	for _, rawURL := range urls {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, err
+		}
+		path := u.EscapedPath()
+		if path == "" {
+			path = "/"
+		}
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+func (p *cloudFrontPurger) Purge(ctx context.Context, urls []string, tags []string) (*Result, error) {
+	result := &Result{}
+	if len(tags) != 0 {
+		return result, fmt.Errorf("cloudfront does not support purging cache tags")
+	}
+	paths, err := cloudFrontPathsOf(urls)
+	if err != nil {
+		return result, err
+	}
+
+	for start := 0; start < len(paths); start += maxCloudFrontPaths {
+		end := min(start+maxCloudFrontPaths, len(paths))
+		id, err := p.createInvalidation(ctx, paths[start:end])
+		if err != nil {
+			return result, err
+		}
+		result.PurgeIDs = append(result.PurgeIDs, id)
+	}
+	return result, nil
+}
+
+func (p *cloudFrontPurger) createInvalidation(ctx context.Context, paths []string) (string, error) {
+	now := p.now()
+	batch := cloudFrontInvalidationBatch{
+		// The reference makes CloudFront ignore a request that is sent twice
+		CallerReference: "frontend-operator-" + strconv.FormatInt(now.UnixNano(), 10),
+		Paths:           cloudFrontPaths{Quantity: len(paths), Items: paths},
+	}
+	body, err := xml.Marshal(batch)
+	if err != nil {
+		return "", err
+	}
+	body = append([]byte(xml.Header), body...)
+
+	endpoint := fmt.Sprintf("%s/2020-05-31/distribution/%s/invalidation", p.BaseURL, url.PathEscape(p.distributionID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "text/xml")
+	p.credentials.sign(req, body, cloudFrontRegion, cloudFrontService, now)
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusCreated {
+		failure := cloudFrontError{}
+		_ = xml.Unmarshal(responseBody, &failure)
+		message := failure.Message
+		if message == "" {
+			message = http.StatusText(resp.StatusCode)
+		}
+		if failure.Code != "" {
+			message = failure.Code + ": " + message
+		}
+		return "", fmt.Errorf("cloudfront invalidation failed with %d: %s", resp.StatusCode, message)
+	}
+
+	invalidation := cloudFrontInvalidation{}
+	if err := xml.Unmarshal(responseBody, &invalidation); err != nil {
+		return "", err
+	}
+	return invalidation.ID, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cdn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// maxFastlySurrogateKeys is the number of surrogate keys a bulk purge accepts
+const maxFastlySurrogateKeys = 256
+
+// fastlyPurger purges URLs and surrogate keys with the Fastly API
+type fastlyPurger struct {
+	// BaseURL of the API, tests point it at a stand-in
+	BaseURL    string
+	HTTPClient *http.Client
+
+	token     string
+	serviceID string
+}
+
+func newFastlyPurger(config Config) (*fastlyPurger, error) {
+	values, err := requireKeys(ProviderFastly, config.Credentials, "api_token")
+	if err != nil {
+		return nil, err
+	}
+	return &fastlyPurger{
+		BaseURL:    "https://api.fastly.com",
+		HTTPClient: newHTTPClient(),
+		token:      values["api_token"],
+		serviceID:  config.FastlyServiceID,
+	}, nil
+}
+
+type fastlyURLPurgeResponse struct {
+	Status string `json:"status"`
+	ID     string `json:"id"`
+}
+
+func (p *fastlyPurger) Purge(ctx context.Context, urls []string, tags []string) (*Result, error) {
+	result := &Result{}
+	if len(tags) != 0 && p.serviceID == "" {
+		return result, fmt.Errorf("fastly cache tags need the fastlyServiceID of the environment")
+	}
+
+	for _, url := range urls {
+		cachedURL := strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://")
+		response := fastlyURLPurgeResponse{}
+		if err := p.post(ctx, "/purge/"+cachedURL, nil, &response); err != nil {
+			return result, fmt.Errorf("fastly purge of %s failed: %w", url, err)
+		}
+		if response.ID != "" {
+			result.PurgeIDs = append(result.PurgeIDs, response.ID)
+		}
+	}
+
+	for start := 0; start < len(tags); start += maxFastlySurrogateKeys {
+		end := min(start+maxFastlySurrogateKeys, len(tags))
+		header := http.Header{"Surrogate-Key": []string{strings.Join(tags[start:end], " ")}}
+		// The response maps each surrogate key to the ID of its purge
+		response := map[string]string{}
+		if err := p.post(ctx, "/service/"+p.serviceID+"/purge", header, &response); err != nil {
+			return result, fmt.Errorf("fastly surrogate key purge failed: %w", err)
+		}
+		ids := []string{}
+		for _, id := range response {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		result.PurgeIDs = append(result.PurgeIDs, ids...)
+	}
+	return result, nil
+}
+
+func (p *fastlyPurger) post(ctx context.Context, path string, header http.Header, response interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.BaseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Fastly-Key", p.token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		failure := struct {
+			Msg    string `json:"msg"`
+			Detail string `json:"detail"`
+		}{}
+		_ = json.Unmarshal(body, &failure)
+		message := strings.TrimSpace(strings.Join([]string{failure.Msg, failure.Detail}, " "))
+		if message == "" {
+			message = http.StatusText(resp.StatusCode)
+		}
+		return fmt.Errorf("status %d: %s", resp.StatusCode, message)
+	}
+	return json.Unmarshal(body, response)
+}
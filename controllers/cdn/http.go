@@ -0,0 +1,124 @@
+/*
+Copyright 2025 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cdn
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// defaultHTTPPurgeMethod is the method Varnish and most caching proxies purge with
+const defaultHTTPPurgeMethod = "PURGE"
+
+// httpPurger requests every URL with the purge method, the cache in front of the frontends
+// drops what it holds for the URL. Cache tags are sent in a header to the root of the hosts.
+type httpPurger struct {
+	HTTPClient *http.Client
+
+	method    string
+	tagHeader string
+	token     string
+}
+
+func newHTTPPurger(config Config) *httpPurger {
+	p := &httpPurger{
+		HTTPClient: newHTTPClient(),
+		method:     config.HTTPMethod,
+		tagHeader:  config.HTTPTagHeader,
+		token:      strings.TrimSpace(string(config.Credentials["token"])),
+	}
+	if p.method == "" {
+		p.method = defaultHTTPPurgeMethod
+	}
+	return p
+}
+
+// rootURLs returns the distinct scheme and host roots of the URLs
+func rootURLs(urls []string) ([]string, error) {
+	roots := []string{}
+	seen := map[string]bool{}
+	for _, rawURL := range urls {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, err
+		}
+		root := u.Scheme + "://" + u.Host + "/"
+		if !seen[root] {
+			seen[root] = true
+			roots = append(roots, root)
+		}
+	}
+	return roots, nil
+}
+
+func (p *httpPurger) Purge(ctx context.Context, urls []string, tags []string) (*Result, error) {
+	result := &Result{}
+	for _, url := range urls {
+		if err := p.request(ctx, url, nil); err != nil {
+			return result, err
+		}
+	}
+
+	if len(tags) == 0 {
+		return result, nil
+	}
+	if p.tagHeader == "" {
+		return result, fmt.Errorf("http cache tags need the httpTagHeader of the environment")
+	}
+	roots, err := rootURLs(urls)
+	if err != nil {
+		return result, err
+	}
+	if len(roots) == 0 {
+		return result, fmt.Errorf("http cache tags need a cache bust URL to be sent to")
+	}
+	for _, root := range roots {
+		if err := p.request(ctx, root, http.Header{p.tagHeader: []string{strings.Join(tags, " ")}}); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func (p *httpPurger) request(ctx context.Context, url string, header http.Header) error {
+	req, err := http.NewRequestWithContext(ctx, p.method, url, nil)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[http.CanonicalHeaderKey(key)] = values
+	}
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s %s failed with %d", p.method, url, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// configAPIFiles maps the files served by the config API to the suffix of the
+// generated config map holding them
+var configAPIFiles = map[string]string{
+	"fed-modules.json":                     "",
+	"bundles.json":                         "",
+	"search-index.json":                    "",
+	"service-tiles.json":                   "",
+	"api-specs.json":                       "",
+	"sso-config.json":                      "",
+	"widget-registry.json":                 WidgetRegistryConfigMapSuffix,
+	"base-widget-dashboard-templates.json": BaseWidgetDashboardTemplatesConfigMapSuffix,
+}
+
+// ConfigAPIServer serves the generated chrome config of each FrontendEnvironment read-only over
+// HTTP, so that tooling does not need access to the config maps in every frontend namespace.
+type ConfigAPIServer struct {
+	Addr   string
+	Client client.Reader
+	Log    logr.Logger
+}
+
+// NeedLeaderElection allows every replica of the manager to serve the API
+func (s *ConfigAPIServer) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable
+func (s *ConfigAPIServer) Start(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:              s.Addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			s.Log.Error(err, "Failed to shut down config API server")
+		}
+	}()
+
+	s.Log.Info("Starting config API server", "addr", s.Addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Handler returns the routes of the config API
+func (s *ConfigAPIServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /environments/{env}", s.listFiles)
+	mux.HandleFunc("GET /environments/{env}/{file}", s.getFile)
+	return mux
+}
+
+func (s *ConfigAPIServer) listFiles(w http.ResponseWriter, r *http.Request) {
+	envName := r.PathValue("env")
+
+	configMaps, err := s.getConfigMaps(r.Context(), envName)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	files := []string{}
+	for file, suffix := range configAPIFiles {
+		if cfgMap, ok := configMaps[envName+suffix]; ok {
+			if _, ok := cfgMap.Data[file]; ok {
+				files = append(files, file)
+			}
+		}
+	}
+	if len(files) == 0 {
+		http.Error(w, fmt.Sprintf("no generated config for environment %q", envName), http.StatusNotFound)
+		return
+	}
+	sort.Strings(files)
+
+	body, err := json.Marshal(map[string][]string{"files": files})
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+func (s *ConfigAPIServer) getFile(w http.ResponseWriter, r *http.Request) {
+	envName := r.PathValue("env")
+	file := r.PathValue("file")
+
+	suffix, ok := configAPIFiles[file]
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown file %q", file), http.StatusNotFound)
+		return
+	}
+
+	configMaps, err := s.getConfigMaps(r.Context(), envName)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	cfgMap, ok := configMaps[envName+suffix]
+	if !ok {
+		http.Error(w, fmt.Sprintf("no generated config for environment %q", envName), http.StatusNotFound)
+		return
+	}
+	data, ok := cfgMap.Data[file]
+	if !ok {
+		http.Error(w, fmt.Sprintf("%s is not generated for environment %q", file, envName), http.StatusNotFound)
+		return
+	}
+
+	hash, err := createConfigmapHash([]map[string]string{{file: data}})
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	etag := fmt.Sprintf("%q", hash)
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(data))
+}
+
+// getConfigMaps returns the generated config maps of the environment by name. All frontend
+// namespaces of an environment get the same content, the first namespace is used.
+func (s *ConfigAPIServer) getConfigMaps(ctx context.Context, envName string) (map[string]*v1.ConfigMap, error) {
+	configMapList := &v1.ConfigMapList{}
+	if err := s.Client.List(ctx, configMapList, client.MatchingLabels{"frontendenv": envName}); err != nil {
+		return nil, err
+	}
+
+	configMaps := map[string]*v1.ConfigMap{}
+	for i := range configMapList.Items {
+		cfgMap := &configMapList.Items[i]
+		if existing, ok := configMaps[cfgMap.Name]; ok && existing.Namespace < cfgMap.Namespace {
+			continue
+		}
+		configMaps[cfgMap.Name] = cfgMap
+	}
+	return configMaps, nil
+}
+
+func (s *ConfigAPIServer) writeError(w http.ResponseWriter, err error) {
+	s.Log.Error(err, "Failed to read generated config")
+	http.Error(w, "failed to read generated config", http.StatusInternalServerError)
+}
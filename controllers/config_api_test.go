@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newConfigAPITestServer() *ConfigAPIServer {
+	labels := map[string]string{"frontendenv": "stage"}
+	objs := []v1.ConfigMap{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "stage", Namespace: "boot", Labels: labels},
+			Data: map[string]string{
+				"fed-modules.json": `{"inventory":{}}`,
+				"Caddyfile":        defaultCaddyfile,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "stage", Namespace: "zz-other", Labels: labels},
+			Data:       map[string]string{"fed-modules.json": `{"stale":{}}`},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "stage-widget-registry", Namespace: "boot", Labels: labels},
+			Data:       map[string]string{"widget-registry.json": `[]`},
+		},
+	}
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for i := range objs {
+		builder = builder.WithObjects(&objs[i])
+	}
+	return &ConfigAPIServer{Client: builder.Build(), Log: logr.Discard()}
+}
+
+func TestConfigAPIServesFilesWithETag(t *testing.T) {
+	handler := newConfigAPITestServer().Handler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/environments/stage/fed-modules.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec.Body.String() != `{"inventory":{}}` {
+		t.Errorf("expected the config of the first namespace, got %s", rec.Body.String())
+	}
+
+	hash, _ := createConfigmapHash([]map[string]string{{"fed-modules.json": `{"inventory":{}}`}})
+	etag := rec.Header().Get("ETag")
+	if etag != `"`+hash+`"` {
+		t.Errorf("expected ETag %q, got %q", hash, etag)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/environments/stage/fed-modules.json", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("expected 304 for a matching ETag, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/environments/stage/widget-registry.json", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != `[]` {
+		t.Errorf("expected the widget registry, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestConfigAPINotFound(t *testing.T) {
+	handler := newConfigAPITestServer().Handler()
+
+	for _, path := range []string{
+		"/environments/stage/Caddyfile",
+		"/environments/stage/bundles.json",
+		"/environments/prod/fed-modules.json",
+		"/environments/prod",
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404 for %s, got %d", path, rec.Code)
+		}
+	}
+}
+
+func TestConfigAPIListsFiles(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newConfigAPITestServer().Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/environments/stage", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if rec.Body.String() != `{"files":["fed-modules.json","widget-registry.json"]}` {
+		t.Errorf("unexpected file list %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"testing"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetupServiceTilesDataSkippedTiles(t *testing.T) {
+	feEnvironment := crd.FrontendEnvironment{
+		Spec: crd.FrontendEnvironmentSpec{
+			ServiceCategories: &[]crd.FrontendServiceCategory{{
+				ID:     "automation",
+				Groups: []crd.FrontendServiceCategoryGroup{{ID: "ansible"}},
+			}},
+		},
+	}
+	feList := &crd.FrontendList{Items: []crd.Frontend{{
+		ObjectMeta: metav1.ObjectMeta{Name: "landing", Namespace: "boot"},
+		Spec: crd.FrontendSpec{
+			FeoConfigEnabled: true,
+			ServiceTiles: []*crd.ServiceTile{
+				{ID: "placed", Section: "automation", Group: "ansible"},
+				{ID: "wrong-group", Section: "automation", Group: "terraform"},
+				{ID: "wrong-section", Section: "iam", Group: "ansible"},
+			},
+		},
+	}}}
+
+	_, skipped := setupServiceTilesData(feList, feEnvironment)
+
+	expected := []string{
+		`service tile wrong-group: unknown group "terraform" in section "automation"`,
+		`service tile wrong-section: unknown section "iam"`,
+	}
+	if len(skipped) != len(expected) {
+		t.Fatalf("expected %d skipped tiles, got %v", len(expected), skipped)
+	}
+	for i, item := range skipped {
+		if item.String() != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], item.String())
+		}
+		if item.FrontendName != "landing" || item.FrontendNamespace != "boot" {
+			t.Errorf("expected the skipped tile to reference boot/landing, got %s/%s", item.FrontendNamespace, item.FrontendName)
+		}
+	}
+}
+
+func TestSetupBundlesDataSkippedSegments(t *testing.T) {
+	feEnvironment := crd.FrontendEnvironment{
+		Spec: crd.FrontendEnvironmentSpec{
+			Bundles: &[]crd.FrontendBundles{{ID: "insights", Title: "Insights"}},
+		},
+	}
+	navItems := []crd.ChromeNavItem{{Title: "Inventory", Href: "/insights/inventory"}}
+	feList := &crd.FrontendList{Items: []crd.Frontend{{
+		ObjectMeta: metav1.ObjectMeta{Name: "inventory", Namespace: "boot"},
+		Spec: crd.FrontendSpec{
+			FeoConfigEnabled: true,
+			BundleSegments: []*crd.BundleSegment{
+				{SegmentID: "known", BundleID: "insights", NavItems: &navItems},
+				{SegmentID: "orphan", BundleID: "openshift", NavItems: &navItems},
+			},
+		},
+	}}}
+
+	_, skipped, _, err := setupBundlesData(feList, feEnvironment)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(skipped) != 1 {
+		t.Fatalf("expected a single skipped segment, got %v", skipped)
+	}
+	if skipped[0].String() != `bundle segment inventory-openshift-orphan: unknown bundle ID "openshift"` {
+		t.Errorf("unexpected skipped segment %q", skipped[0].String())
+	}
+}
+
+func TestConfigurationWarningsCondition(t *testing.T) {
+	condition := ConfigurationWarningsCondition(nil)
+	if condition.Status != metav1.ConditionFalse || condition.Reason != "NoWarnings" {
+		t.Errorf("expected a false condition without warnings, got %v", condition)
+	}
+
+	condition = ConfigurationWarningsCondition([]string{"service tile a: unknown section \"x\"", "bundle segment b: unknown bundle ID \"y\""})
+	if condition.Status != metav1.ConditionTrue || condition.Type != crd.ConfigurationWarnings {
+		t.Errorf("expected a true ConfigurationWarnings condition, got %v", condition)
+	}
+	expected := "Skipped configuration: service tile a: unknown section \"x\"; bundle segment b: unknown bundle ID \"y\""
+	if condition.Message != expected {
+		t.Errorf("expected message %q, got %q", expected, condition.Message)
+	}
+}
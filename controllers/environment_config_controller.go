@@ -0,0 +1,319 @@
+/*
+Copyright 2025 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	resCache "github.com/RedHatInsights/rhc-osdk-utils/resourceCache"
+	"github.com/go-logr/logr"
+)
+
+// DefaultConfigDebounce is how long changes to the Frontends, Bundles and FrontendEnvironment
+// of an environment are collected before its config is generated.
+const DefaultConfigDebounce = 2 * time.Second
+
+// EnvironmentConfigReconciler generates the config maps of a FrontendEnvironment once for all
+// of its Frontends. Reconcile requests only carry the environment name and wait for the
+// changes of the environment to settle, so a burst of changes collapses into a single
+// generation.
+type EnvironmentConfigReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// Debounce delays the generation after a change, DefaultConfigDebounce when unset
+	Debounce time.Duration
+
+	debouncer *environmentDebouncer
+}
+
+//+kubebuilder:rbac:groups=cloud.redhat.com,resources=frontends,verbs=get;list;watch
+//+kubebuilder:rbac:groups=cloud.redhat.com,resources=frontends/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=cloud.redhat.com,resources=frontendenvironments,verbs=get;list;watch
+//+kubebuilder:rbac:groups=cloud.redhat.com,resources=frontendenvironments/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=cloud.redhat.com,resources=bundles,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps;events,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile generates the config of a FrontendEnvironment and reports the result on the
+// Frontends and the FrontendEnvironment status
+func (r *EnvironmentConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.Log = log.FromContext(ctx)
+	log := r.Log.WithValues("frontendenvironment", req.Name)
+
+	// Changes keep arriving, the config is generated once they settle
+	if wait := r.debouncer.remaining(req.Name); wait > 0 {
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	fe := &crd.FrontendEnvironment{}
+	if err := r.Client.Get(ctx, req.NamespacedName, fe); err != nil {
+		if k8serr.IsNotFound(err) {
+			// Environment was deleted, the config maps are garbage collected
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Generating environment config")
+
+	var reconciliation EnvironmentConfigReconciliation
+	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Client.Get(ctx, req.NamespacedName, fe); err != nil {
+			return err
+		}
+		feList, err := fe.GetFrontendsInEnv(ctx, r.Client)
+		if err != nil {
+			return err
+		}
+
+		cacheConfig := resCache.NewCacheConfig(scheme, nil, nil, resCache.Options{})
+		cache := resCache.NewObjectCache(ctx, r.Client, &log, cacheConfig)
+		cache.AddPossibleGVKFromIdent(CoreConfig)
+
+		reconciliation = EnvironmentConfigReconciliation{
+			Log:                 log,
+			Cache:               cache,
+			Client:              r.Client,
+			Ctx:                 ctx,
+			FrontendEnvironment: fe,
+			Frontends:           feList,
+		}
+		if err := reconciliation.run(); err != nil {
+			return err
+		}
+		if err := cache.ApplyAll(); err != nil {
+			return err
+		}
+		// Config maps left in namespaces without Frontends or TargetNamespaces are removed
+		return cache.Reconcile(fe.GetUID(), client.MatchingLabels{"frontendenv": fe.Name})
+	})
+	if retryErr != nil {
+		log.Error(retryErr, "Failed to generate environment config")
+		return ctrl.Result{}, retryErr
+	}
+
+	r.setFrontendConditions(ctx, log, &reconciliation)
+
+	if err := SetFrontendEnvironmentStatus(ctx, r.Client, fe.Name); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Generated environment config", "frontends", len(reconciliation.Frontends.Items))
+	return ctrl.Result{}, nil
+}
+
+// setFrontendConditions keeps the ConfigurationWarnings and NavigationReferencesResolved
+// conditions of the Frontends in line with the generated config
+func (r *EnvironmentConfigReconciler) setFrontendConditions(ctx context.Context, log logr.Logger, reconciliation *EnvironmentConfigReconciliation) {
+	for i := range reconciliation.Frontends.Items {
+		frontend := &reconciliation.Frontends.Items[i]
+		nn := types.NamespacedName{Name: frontend.Name, Namespace: frontend.Namespace}
+
+		warnings, ok := reconciliation.ConfigurationWarnings[nn]
+		if !ok {
+			continue
+		}
+		warningsCondition := ConfigurationWarningsCondition(warnings)
+		r.recordConfigurationWarnings(frontend, warningsCondition)
+		conditions := []metav1.Condition{warningsCondition}
+		if issues, ok := reconciliation.NavigationReferenceIssues[nn]; ok {
+			conditions = append(conditions, NavigationReferencesCondition(issues))
+		}
+
+		if err := SetFrontendCondition(ctx, r.Client, nn, conditions...); err != nil && !k8serr.IsNotFound(err) {
+			log.Error(err, "Failed to update Frontend conditions", "frontend", nn.String())
+		}
+	}
+}
+
+// recordConfigurationWarnings emits a warning event when the skipped configuration of a
+// Frontend changes, the same list is kept in the ConfigurationWarnings condition.
+func (r *EnvironmentConfigReconciler) recordConfigurationWarnings(frontend *crd.Frontend, condition metav1.Condition) {
+	if r.Recorder == nil || condition.Status != metav1.ConditionTrue {
+		return
+	}
+	existing := meta.FindStatusCondition(frontend.Status.Conditions, crd.ConfigurationWarnings)
+	if existing != nil && existing.Status == condition.Status && existing.Message == condition.Message {
+		return
+	}
+	r.Recorder.Event(frontend, v1.EventTypeWarning, condition.Reason, condition.Message)
+}
+
+// SetupWithManager sets up the controller with the Manager. It relies on the spec.envName
+// indexes registered by the FrontendReconciler.
+func (r *EnvironmentConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	debounce := r.Debounce
+	if debounce == 0 {
+		debounce = DefaultConfigDebounce
+	}
+	r.debouncer = newEnvironmentDebouncer(debounce)
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		Named("environmentconfig").
+		Watches(
+			&crd.FrontendEnvironment{},
+			r.debouncer.enqueue(func(o client.Object) string { return o.GetName() }),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&crd.Frontend{},
+			r.debouncer.enqueue(func(o client.Object) string { return o.(*crd.Frontend).Spec.EnvName }),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&crd.Bundle{},
+			r.debouncer.enqueue(func(o client.Object) string { return o.(*crd.Bundle).Spec.EnvName }),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		// Generated config maps that are deleted or edited by hand are generated again
+		Watches(
+			&v1.ConfigMap{},
+			r.debouncer.enqueue(func(o client.Object) string { return o.GetLabels()["frontendenv"] }),
+			builder.WithPredicates(environmentConfigMapPredicate()),
+		).
+		Complete(r)
+	if err != nil {
+		return err
+	}
+
+	// The ready status of a Frontend only changes the ready count of the environment status,
+	// so it is handled without generating the config again
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("environmentstatus").
+		Watches(
+			&crd.Frontend{},
+			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, o client.Object) []reconcile.Request {
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: o.(*crd.Frontend).Spec.EnvName}}}
+			}),
+			builder.WithPredicates(frontendReadyChangedPredicate()),
+		).
+		Complete(reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+			if err := SetFrontendEnvironmentStatus(ctx, r.Client, req.Name); err != nil && !k8serr.IsNotFound(err) {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{}, nil
+		}))
+}
+
+// frontendReadyChangedPredicate passes Frontend updates that flip the ready status
+func frontendReadyChangedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldFrontend, ok := e.ObjectOld.(*crd.Frontend)
+			if !ok {
+				return false
+			}
+			newFrontend, ok := e.ObjectNew.(*crd.Frontend)
+			if !ok {
+				return false
+			}
+			return oldFrontend.Status.Ready != newFrontend.Status.Ready
+		},
+	}
+}
+
+// environmentDebouncer delays the generation of an environment config until its changes
+// settle. Every change moves the generation of the environment to the debounce delay after
+// it, so a burst of changes is handled by a single reconcile however long it lasts.
+type environmentDebouncer struct {
+	delay time.Duration
+
+	mu          sync.Mutex
+	lastChanges map[string]time.Time
+}
+
+func newEnvironmentDebouncer(delay time.Duration) *environmentDebouncer {
+	return &environmentDebouncer{delay: delay, lastChanges: map[string]time.Time{}}
+}
+
+// remaining returns how long the generation of the environment still has to wait for its
+// changes to settle
+func (d *environmentDebouncer) remaining(name string) time.Duration {
+	if d == nil {
+		return 0
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	lastChange, ok := d.lastChanges[name]
+	if !ok {
+		return 0
+	}
+	if wait := d.delay - time.Since(lastChange); wait > 0 {
+		return wait
+	}
+	delete(d.lastChanges, name)
+	return 0
+}
+
+// enqueue records a change of the environment of an object and enqueues it after the
+// debounce delay
+func (d *environmentDebouncer) enqueue(envName func(client.Object) string) handler.EventHandler {
+	enqueue := func(q workqueue.TypedRateLimitingInterface[reconcile.Request], objs ...client.Object) {
+		for _, obj := range objs {
+			name := envName(obj)
+			if name == "" {
+				continue
+			}
+			d.mu.Lock()
+			d.lastChanges[name] = time.Now()
+			d.mu.Unlock()
+			q.AddAfter(reconcile.Request{NamespacedName: types.NamespacedName{Name: name}}, d.delay)
+		}
+	}
+
+	return handler.Funcs{
+		CreateFunc: func(_ context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(q, e.Object)
+		},
+		// A Frontend moving between environments changes the config of both
+		UpdateFunc: func(_ context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(q, e.ObjectOld, e.ObjectNew)
+		},
+		DeleteFunc: func(_ context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(q, e.Object)
+		},
+		GenericFunc: func(_ context.Context, e event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(q, e.Object)
+		},
+	}
+}
@@ -0,0 +1,330 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func environmentConfigTestClient(objs ...client.Object) client.Client {
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&crd.Frontend{}, &crd.FrontendEnvironment{}).
+		WithIndex(&crd.Frontend{}, "spec.envName", func(o client.Object) []string {
+			return []string{o.(*crd.Frontend).Spec.EnvName}
+		}).
+		WithIndex(&crd.Bundle{}, "spec.envName", func(o client.Object) []string {
+			return []string{o.(*crd.Bundle).Spec.EnvName}
+		}).
+		Build()
+}
+
+func TestEnvironmentConfigReconcilerGeneratesConfigOnce(t *testing.T) {
+	feEnv := &crd.FrontendEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "stage", UID: "stage-uid"},
+		Spec: crd.FrontendEnvironmentSpec{
+			SSO:              "https://sso.example.com",
+			TargetNamespaces: []string{"chrome"},
+			ServiceCategories: &[]crd.FrontendServiceCategory{{
+				ID:     "automation",
+				Groups: []crd.FrontendServiceCategoryGroup{{ID: "ansible"}},
+			}},
+		},
+	}
+	frontend := func(name, namespace string, tiles ...*crd.ServiceTile) *crd.Frontend {
+		return &crd.Frontend{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: crd.FrontendSpec{
+				EnvName:          "stage",
+				FeoConfigEnabled: true,
+				Module: &crd.FedModule{
+					ManifestLocation: "/apps/" + name + "/fed-mods.json",
+					Modules:          []crd.Module{{ID: name, Module: "./RootApp"}},
+				},
+				ServiceTiles: tiles,
+			},
+		}
+	}
+	pClient := environmentConfigTestClient(
+		feEnv,
+		frontend("landing", "boot"),
+		frontend("inventory", "inventory-beta", &crd.ServiceTile{ID: "hosts", Section: "unknown"}),
+		frontend("other-env", "boot"),
+	)
+	otherEnv := &crd.Frontend{}
+	if err := pClient.Get(context.Background(), types.NamespacedName{Name: "other-env", Namespace: "boot"}, otherEnv); err != nil {
+		t.Fatal(err)
+	}
+	otherEnv.Spec.EnvName = "prod"
+	if err := pClient.Update(context.Background(), otherEnv); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &EnvironmentConfigReconciler{Client: pClient, Scheme: scheme, Log: ctrl.Log}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "stage"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []types.NamespacedName{
+		{Name: "stage", Namespace: "boot"},
+		{Name: "stage", Namespace: "inventory-beta"},
+		{Name: "stage-widget-registry", Namespace: "boot"},
+		{Name: "stage-base-widget-dashboard-templates", Namespace: "inventory-beta"},
+		{Name: FrontendContextConfigMapName, Namespace: "chrome"},
+		{Name: FrontendContextConfigMapName + "-beta", Namespace: "chrome"},
+		{Name: WidgetRegistryContextConfigMapName, Namespace: "chrome"},
+		{Name: BaseWidgetDashboardTemplatesContextName, Namespace: "chrome"},
+	}
+	configMaps := map[types.NamespacedName]*v1.ConfigMap{}
+	for _, nn := range expected {
+		cfgMap := &v1.ConfigMap{}
+		if err := pClient.Get(context.Background(), nn, cfgMap); err != nil {
+			t.Fatalf("expected config map %s: %v", nn, err)
+		}
+		configMaps[nn] = cfgMap
+	}
+
+	fedModules := configMaps[types.NamespacedName{Name: "stage", Namespace: "boot"}].Data["fed-modules.json"]
+	if fedModules == "" {
+		t.Fatal("expected fed-modules.json to be generated")
+	}
+	for _, nn := range []types.NamespacedName{expected[1], expected[4], expected[5]} {
+		if configMaps[nn].Data["fed-modules.json"] != fedModules {
+			t.Errorf("expected %s to carry the same fed-modules.json, got %q", nn, configMaps[nn].Data["fed-modules.json"])
+		}
+	}
+	if err := pClient.Get(context.Background(), types.NamespacedName{Name: "prod", Namespace: "boot"}, &v1.ConfigMap{}); err == nil {
+		t.Error("expected no config map for the Frontend of another environment")
+	}
+
+	inventory := &crd.Frontend{}
+	if err := pClient.Get(context.Background(), types.NamespacedName{Name: "inventory", Namespace: "inventory-beta"}, inventory); err != nil {
+		t.Fatal(err)
+	}
+	if !meta.IsStatusConditionTrue(inventory.Status.Conditions, crd.ConfigurationWarnings) {
+		t.Errorf("expected the skipped tile to be reported on inventory, got %v", inventory.Status.Conditions)
+	}
+	landing := &crd.Frontend{}
+	if err := pClient.Get(context.Background(), types.NamespacedName{Name: "landing", Namespace: "boot"}, landing); err != nil {
+		t.Fatal(err)
+	}
+	if !meta.IsStatusConditionFalse(landing.Status.Conditions, crd.ConfigurationWarnings) {
+		t.Errorf("expected no configuration warnings on landing, got %v", landing.Status.Conditions)
+	}
+	if !meta.IsStatusConditionTrue(landing.Status.Conditions, crd.NavigationReferencesResolved) {
+		t.Errorf("expected resolved navigation references on landing, got %v", landing.Status.Conditions)
+	}
+
+	updatedEnv := &crd.FrontendEnvironment{}
+	if err := pClient.Get(context.Background(), types.NamespacedName{Name: "stage"}, updatedEnv); err != nil {
+		t.Fatal(err)
+	}
+	if updatedEnv.Status.Frontends != 2 {
+		t.Errorf("expected the environment status to count 2 frontends, got %d", updatedEnv.Status.Frontends)
+	}
+}
+
+func TestEnvironmentDebouncerCollapsesChanges(t *testing.T) {
+	q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	defer q.ShutDown()
+
+	h := newEnvironmentDebouncer(50 * time.Millisecond).enqueue(func(o client.Object) string {
+		return o.(*crd.Frontend).Spec.EnvName
+	})
+
+	frontend := func(name, envName string) *crd.Frontend {
+		return &crd.Frontend{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "boot"},
+			Spec:       crd.FrontendSpec{EnvName: envName},
+		}
+	}
+
+	ctx := context.Background()
+	h.Create(ctx, event.CreateEvent{Object: frontend("a", "stage")}, q)
+	h.Create(ctx, event.CreateEvent{Object: frontend("b", "stage")}, q)
+	h.Update(ctx, event.UpdateEvent{ObjectOld: frontend("c", "stage"), ObjectNew: frontend("c", "prod")}, q)
+	h.Delete(ctx, event.DeleteEvent{Object: frontend("d", "stage")}, q)
+
+	if q.Len() != 0 {
+		t.Fatalf("expected the requests to wait for the debounce delay, got %d", q.Len())
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	if q.Len() != 2 {
+		t.Fatalf("expected one request per environment, got %d", q.Len())
+	}
+	envs := map[string]bool{}
+	for q.Len() > 0 {
+		req, _ := q.Get()
+		envs[req.Name] = true
+		q.Done(req)
+	}
+	if !envs["stage"] || !envs["prod"] {
+		t.Errorf("expected stage and prod to be enqueued, got %v", envs)
+	}
+}
+
+func TestEnvironmentConfigReconcilerRestoresConfigMaps(t *testing.T) {
+	feEnv := &crd.FrontendEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "stage", UID: "stage-uid"},
+		Spec:       crd.FrontendEnvironmentSpec{SSO: "https://sso.example.com"},
+	}
+	landing := &crd.Frontend{
+		ObjectMeta: metav1.ObjectMeta{Name: "landing", Namespace: "boot"},
+		Spec: crd.FrontendSpec{
+			EnvName:          "stage",
+			FeoConfigEnabled: true,
+			Module: &crd.FedModule{
+				ManifestLocation: "/apps/landing/fed-mods.json",
+				Modules:          []crd.Module{{ID: "landing", Module: "./RootApp"}},
+			},
+		},
+	}
+	pClient := environmentConfigTestClient(feEnv, landing)
+	r := &EnvironmentConfigReconciler{Client: pClient, Scheme: scheme, Log: ctrl.Log}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "stage"}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	configNN := types.NamespacedName{Name: "stage", Namespace: "boot"}
+	registryNN := types.NamespacedName{Name: "stage-widget-registry", Namespace: "boot"}
+	generated := &v1.ConfigMap{}
+	if err := pClient.Get(context.Background(), configNN, generated); err != nil {
+		t.Fatal(err)
+	}
+	if err := pClient.Delete(context.Background(), generated); err != nil {
+		t.Fatal(err)
+	}
+	registry := &v1.ConfigMap{}
+	if err := pClient.Get(context.Background(), registryNN, registry); err != nil {
+		t.Fatal(err)
+	}
+	generatedRegistry := registry.Data
+	registry.Data = map[string]string{"widget-registry.json": "edited"}
+	if err := pClient.Update(context.Background(), registry); err != nil {
+		t.Fatal(err)
+	}
+
+	h := newEnvironmentDebouncer(0).enqueue(func(o client.Object) string { return o.GetLabels()["frontendenv"] })
+	q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	defer q.ShutDown()
+	if !environmentConfigMapPredicate().Delete(event.DeleteEvent{Object: generated}) {
+		t.Fatal("expected the generated config map to pass the predicate")
+	}
+	h.Delete(context.Background(), event.DeleteEvent{Object: generated}, q)
+	if enqueued, _ := q.Get(); enqueued != req {
+		t.Fatalf("expected the environment to be enqueued, got %v", enqueued)
+	}
+
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	restored := &v1.ConfigMap{}
+	if err := pClient.Get(context.Background(), configNN, restored); err != nil {
+		t.Fatalf("expected the deleted config map to be generated again: %v", err)
+	}
+	if restored.Data["fed-modules.json"] != generated.Data["fed-modules.json"] {
+		t.Errorf("expected the same fed-modules.json, got %q", restored.Data["fed-modules.json"])
+	}
+	if err := pClient.Get(context.Background(), registryNN, registry); err != nil {
+		t.Fatal(err)
+	}
+	if registry.Data["widget-registry.json"] != generatedRegistry["widget-registry.json"] {
+		t.Errorf("expected the edited config map to be restored, got %q", registry.Data["widget-registry.json"])
+	}
+}
+
+func TestEnvironmentDebouncerWaitsForChangesToSettle(t *testing.T) {
+	q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	defer q.ShutDown()
+
+	debouncer := newEnvironmentDebouncer(100 * time.Millisecond)
+	h := debouncer.enqueue(func(o client.Object) string { return o.(*crd.Frontend).Spec.EnvName })
+	frontend := &crd.Frontend{
+		ObjectMeta: metav1.ObjectMeta{Name: "landing", Namespace: "boot"},
+		Spec:       crd.FrontendSpec{EnvName: "stage"},
+	}
+
+	h.Create(context.Background(), event.CreateEvent{Object: frontend}, q)
+	time.Sleep(80 * time.Millisecond)
+	h.Update(context.Background(), event.UpdateEvent{ObjectOld: frontend, ObjectNew: frontend}, q)
+	time.Sleep(40 * time.Millisecond)
+
+	// The first request is due, but the second change has not settled yet
+	wait := debouncer.remaining("stage")
+	if wait <= 0 || wait > 100*time.Millisecond {
+		t.Fatalf("expected the generation to wait for the last change, got %s", wait)
+	}
+	r := &EnvironmentConfigReconciler{Client: environmentConfigTestClient(), Scheme: scheme, Log: ctrl.Log, debouncer: debouncer}
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "stage"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter <= 0 {
+		t.Errorf("expected the reconcile to be requeued until the changes settle, got %v", result)
+	}
+
+	time.Sleep(wait)
+	if wait := debouncer.remaining("stage"); wait != 0 {
+		t.Errorf("expected the changes to have settled, got %s", wait)
+	}
+}
+
+func TestEnvironmentConfigReconcilerRemovesStaleConfigMaps(t *testing.T) {
+	feEnv := &crd.FrontendEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "stage", UID: "stage-uid"},
+		Spec:       crd.FrontendEnvironmentSpec{SSO: "https://sso.example.com", TargetNamespaces: []string{"chrome"}},
+	}
+	frontend := func(name, namespace string) *crd.Frontend {
+		return &crd.Frontend{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       crd.FrontendSpec{EnvName: "stage"},
+		}
+	}
+	pClient := environmentConfigTestClient(feEnv, frontend("landing", "boot"), frontend("inventory", "inventory"))
+	r := &EnvironmentConfigReconciler{Client: pClient, Scheme: scheme, Log: ctrl.Log}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "stage"}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, nn := range []types.NamespacedName{{Name: "stage", Namespace: "inventory"}, {Name: "stage-widget-registry", Namespace: "inventory"}} {
+		if err := pClient.Get(context.Background(), nn, &v1.ConfigMap{}); err != nil {
+			t.Fatalf("expected config map %s: %v", nn, err)
+		}
+	}
+
+	if err := pClient.Delete(context.Background(), frontend("inventory", "inventory")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	configMaps := &v1.ConfigMapList{}
+	if err := pClient.List(context.Background(), configMaps, client.InNamespace("inventory")); err != nil {
+		t.Fatal(err)
+	}
+	if len(configMaps.Items) != 0 {
+		t.Errorf("expected the config maps of the namespace without Frontends to be removed, got %d", len(configMaps.Items))
+	}
+	for _, nn := range []types.NamespacedName{{Name: "stage", Namespace: "boot"}, {Name: FrontendContextConfigMapName, Namespace: "chrome"}} {
+		if err := pClient.Get(context.Background(), nn, &v1.ConfigMap{}); err != nil {
+			t.Errorf("expected config map %s to be kept: %v", nn, err)
+		}
+	}
+}
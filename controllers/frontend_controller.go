@@ -22,9 +22,11 @@ import (
 	"time"
 
 	apps "k8s.io/api/apps/v1"
+	autoscaling "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
+	policy "k8s.io/api/policy/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -41,8 +43,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	"github.com/RedHatInsights/frontend-operator/controllers/cdn"
+	"github.com/RedHatInsights/frontend-operator/controllers/registry"
 	resCache "github.com/RedHatInsights/rhc-osdk-utils/resourceCache"
+	routev1 "github.com/openshift/api/route/v1"
 	prom "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/RedHatInsights/rhc-osdk-utils/utils"
 	"github.com/go-logr/logr"
@@ -60,6 +66,8 @@ func createNewScheme() *runtime.Scheme {
 	scheme := runtime.NewScheme()
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(prom.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1.Install(scheme))
+	utilruntime.Must(routev1.Install(scheme))
 	utilruntime.Must(crd.AddToScheme(scheme))
 
 	return scheme
@@ -68,11 +76,17 @@ func createNewScheme() *runtime.Scheme {
 var scheme = createNewScheme()
 
 var CoreDeployment = resCache.NewSingleResourceIdent("main", "deployment", &apps.Deployment{})
+var CanaryDeployment = resCache.NewSingleResourceIdent("main", "canary_deployment", &apps.Deployment{})
 var CoreJob = resCache.NewSingleResourceIdent("main", "job", &batchv1.Job{})
+var FrontendHPA = resCache.NewSingleResourceIdent("main", "hpa", &autoscaling.HorizontalPodAutoscaler{})
+var FrontendPDB = resCache.NewSingleResourceIdent("main", "pdb", &policy.PodDisruptionBudget{})
 var CoreService = resCache.NewSingleResourceIdent("main", "service", &v1.Service{})
+var CanaryService = resCache.NewSingleResourceIdent("main", "canary_service", &v1.Service{})
 var CoreConfig = resCache.NewSingleResourceIdent("main", "config", &v1.ConfigMap{})
 var SSOConfig = resCache.NewSingleResourceIdent("main", "sso_config", &v1.ConfigMap{})
 var WebIngress = resCache.NewMultiResourceIdent("ingress", "web_ingress", &networking.Ingress{})
+var FrontendHTTPRoute = resCache.NewSingleResourceIdent("main", "httproute", &gatewayv1.HTTPRoute{})
+var FrontendRoutes = resCache.NewMultiResourceIdent("main", "routes", &routev1.Route{})
 var MetricsServiceMonitor = resCache.NewMultiResourceIdent("main", "metrics-service-monitor", &prom.ServiceMonitor{})
 
 type ReconciliationMetrics struct {
@@ -96,9 +110,13 @@ func (rm *ReconciliationMetrics) stop() {
 // FrontendReconciler reconciles a Frontend object
 type FrontendReconciler struct {
 	client.Client
-	Log                   logr.Logger
-	Scheme                *runtime.Scheme
-	Recorder              record.EventRecorder
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// NewResolver returns the client resolving the image digests, defaults to registry.NewResolver
+	NewResolver func(registry.Config) (registry.Resolver, error)
+	// NewPurger returns the client purging the CDN cache, defaults to cdn.NewPurger
+	NewPurger             func(cdn.Config) (cdn.Purger, error)
 	reconciliationMetrics ReconciliationMetrics
 }
 
@@ -117,8 +135,14 @@ type FrontendReconciler struct {
 // +kubebuilder:rbac:groups="",resources=serviceaccounts;configmaps;services;secrets;persistentvolumeclaims;events;namespaces,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=batch,resources=cronjobs;jobs,verbs=get;list;create;update;watch;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes/custom-host,verbs=create
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes/status,verbs=get
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheuses;servicemonitors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=endpoints;pods,verbs=get;list;watch
 
@@ -185,9 +209,10 @@ func (r *FrontendReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	ctx = context.WithValue(ctx, FEKey("obj"), &frontend)
 
 	// RetryOnConflict handles 409 conflicts caused by the reconciler racing
-	// against itself (Owns watch triggers, FrontendEnvironment/Bundle fan-out).
+	// against itself (Owns watch triggers, FrontendEnvironment fan-out).
 	// Each retry re-fetches resources to get the latest resourceVersion.
 	var cache resCache.ObjectCache
+	var requeueAfter time.Duration
 	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		// Re-fetch Frontend and FrontendEnvironment on each retry to get the
 		// latest resourceVersion, preventing stale-object 409 loops.
@@ -202,12 +227,20 @@ func (r *FrontendReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		cache = resCache.NewObjectCache(ctx, r.Client, &log, cacheConfig)
 		cache.AddPossibleGVKFromIdent(
 			CoreDeployment,
+			FrontendHPA,
+			FrontendPDB,
 			CoreService,
-			CoreConfig,
 			SSOConfig,
 			WebIngress,
 			MetricsServiceMonitor,
 		)
+		// The Gateway API CRDs and the Route API are only required in environments that use them
+		if fe.UsesGatewayAPI() {
+			cache.AddPossibleGVKFromIdent(FrontendHTTPRoute)
+		}
+		if fe.UsesRoutes() {
+			cache.AddPossibleGVKFromIdent(FrontendRoutes)
+		}
 
 		reconciliation := FrontendReconciliation{
 			Log:                 log,
@@ -218,11 +251,14 @@ func (r *FrontendReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			Ctx:                 ctx,
 			Frontend:            &frontend,
 			Client:              r.Client,
+			NewPurger:           r.NewPurger,
+			NewResolver:         r.NewResolver,
 		}
 
 		if err := reconciliation.run(); err != nil {
 			return err
 		}
+		requeueAfter = reconciliation.RequeueAfter
 
 		return cache.ApplyAll()
 	})
@@ -259,7 +295,7 @@ func (r *FrontendReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 
 	log.Info("Finished reconcile")
 	r.reconciliationMetrics.stop()
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -281,23 +317,39 @@ func (r *FrontendReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&crd.Frontend{}, builder.WithPredicates(defaultPredicate(r.Log, "frontend"))).
+		// The config maps are generated by the EnvironmentConfigReconciler, a change rolls
+		// the Deployments of the environment in the config map's namespace.
 		Watches(
-			&crd.Bundle{},
-			handler.EnqueueRequestsFromMapFunc(r.appsToEnqueueUponBundleUpdate()),
+			&v1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.appsToEnqueueUponConfigMapUpdate()),
+			builder.WithPredicates(environmentConfigMapPredicate()),
 		).
+		// Status-only updates of the FrontendEnvironment must not fan out to its Frontends.
 		Watches(
 			&crd.FrontendEnvironment{},
 			handler.EnqueueRequestsFromMapFunc(r.appsToEnqueueUponFrontendEnvironmentUpdate()),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
 		).
 		// GenerationChangedPredicate filters out status-only updates (e.g. pod
 		// readiness) that don't change metadata.generation, preventing unnecessary
 		// reconciliations and 409 conflicts (RHCLOUD-46492).
 		Owns(&apps.Deployment{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&autoscaling.HorizontalPodAutoscaler{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&policy.PodDisruptionBudget{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Owns(&networking.Ingress{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Owns(&prom.ServiceMonitor{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Complete(r)
+		// Status updates of the push cache Job are reported on the Frontend
+		Owns(&batchv1.Job{}, builder.WithPredicates(pushCacheJobPredicate()))
+
+	// Routes are only watched on OpenShift, status updates carry the admission reported on
+	// the Frontend
+	if _, err := mgr.GetRESTMapper().RESTMapping(routev1.GroupVersion.WithKind("Route").GroupKind(), routev1.GroupVersion.Version); err == nil {
+		b = b.Owns(&routev1.Route{})
+	}
+
+	return b.Complete(r)
 }
 
 func logMessage(logr logr.Logger, msg string, keysAndValues ...interface{}) {
@@ -329,39 +381,28 @@ func defaultPredicate(logr logr.Logger, ctrlName string) predicate.Funcs {
 	}
 }
 
-func (r *FrontendReconciler) appsToEnqueueUponBundleUpdate() handler.MapFunc {
+// environmentConfigMapPredicate passes the config maps generated for a FrontendEnvironment
+func environmentConfigMapPredicate() predicate.Funcs {
+	return predicate.NewPredicateFuncs(func(o client.Object) bool {
+		_, ok := o.GetLabels()["frontendenv"]
+		return ok
+	})
+}
+
+func (r *FrontendReconciler) appsToEnqueueUponConfigMapUpdate() handler.MapFunc {
 	return func(ctx context.Context, clientObject client.Object) []reconcile.Request {
 		reqs := []reconcile.Request{}
-		obj := types.NamespacedName{
-			Name:      clientObject.GetName(),
-			Namespace: clientObject.GetNamespace(),
-		}
-
-		// Get the Bundle resource
-
-		bundle := crd.Bundle{}
-		err := r.Client.Get(ctx, obj, &bundle)
-
-		if err != nil {
-			if k8serr.IsNotFound(err) {
-				// Must have been deleted
-				return reqs
-			}
-			r.Log.Error(err, "Failed to fetch Bundle")
-			return nil
-		}
-
-		// Get all the ClowdApp resources
 
+		// Only the Frontends of the environment in the config map's namespace mount it
 		frontendList := crd.FrontendList{}
-		err = r.Client.List(ctx, &frontendList, client.MatchingFields{"spec.envName": bundle.Spec.EnvName})
-		if err != nil {
+		if err := r.Client.List(ctx, &frontendList,
+			client.InNamespace(clientObject.GetNamespace()),
+			client.MatchingFields{"spec.envName": clientObject.GetLabels()["frontendenv"]},
+		); err != nil {
 			r.Log.Error(err, "Failed to List Frontends")
 			return nil
 		}
 
-		// Filter based on base attribute
-
 		for _, frontend := range frontendList.Items {
 			reqs = append(reqs, reconcile.Request{
 				NamespacedName: types.NamespacedName{
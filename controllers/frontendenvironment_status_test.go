@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"testing"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func statusTestFrontend(name, namespace string, ready, feoConfigEnabled bool) crd.Frontend {
+	return crd.Frontend{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       crd.FrontendSpec{EnvName: "env", FeoConfigEnabled: feoConfigEnabled},
+		Status:     crd.FrontendStatus{Ready: ready},
+	}
+}
+
+func statusTestConfigMap(name, namespace string) v1.ConfigMap {
+	return v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Data:       map[string]string{"fed-modules.json": "{}"},
+	}
+}
+
+func TestPopulateFrontendEnvironmentStatus(t *testing.T) {
+	now := metav1.Now()
+	deleted := statusTestFrontend("deleted", "ns-a", true, true)
+	deleted.DeletionTimestamp = &now
+
+	fe := &crd.FrontendEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "env"},
+		Spec:       crd.FrontendEnvironmentSpec{TargetNamespaces: []string{"target"}},
+	}
+	frontends := []crd.Frontend{
+		statusTestFrontend("chrome", "ns-b", true, true),
+		statusTestFrontend("inventory", "ns-a", false, true),
+		statusTestFrontend("legacy", "ns-a", true, false),
+		deleted,
+	}
+	configMaps := []v1.ConfigMap{
+		statusTestConfigMap("env", "ns-b"),
+		statusTestConfigMap("env-widget-registry", "ns-b"),
+		statusTestConfigMap("env-base-widget-dashboard-templates", "ns-b"),
+		statusTestConfigMap("env", "ns-a"),
+		statusTestConfigMap("env-widget-registry", "ns-a"),
+		statusTestConfigMap("env-base-widget-dashboard-templates", "ns-a"),
+		statusTestConfigMap("feo-context-cfg", "target"),
+		statusTestConfigMap("widget-registry-cfg", "target"),
+		statusTestConfigMap("base-widget-dashboard-templates-cfg", "target"),
+		statusTestConfigMap("env-sso", "ns-a"),
+	}
+
+	populateFrontendEnvironmentStatus(fe, frontends, configMaps, nil)
+
+	if fe.Status.Frontends != 3 || fe.Status.ReadyFrontends != 2 {
+		t.Errorf("expected 2/3 ready frontends, got %d/%d", fe.Status.ReadyFrontends, fe.Status.Frontends)
+	}
+
+	expectedEnabled := []string{"ns-a/inventory", "ns-b/chrome"}
+	if len(fe.Status.FeoConfigEnabledFrontends) != len(expectedEnabled) {
+		t.Fatalf("expected %v, got %v", expectedEnabled, fe.Status.FeoConfigEnabledFrontends)
+	}
+	for i, name := range expectedEnabled {
+		if fe.Status.FeoConfigEnabledFrontends[i] != name {
+			t.Errorf("expected %v, got %v", expectedEnabled, fe.Status.FeoConfigEnabledFrontends)
+		}
+	}
+
+	expectedConfigMaps := []string{
+		"ns-a/env", "ns-a/env-base-widget-dashboard-templates", "ns-a/env-widget-registry",
+		"ns-b/env", "ns-b/env-base-widget-dashboard-templates", "ns-b/env-widget-registry",
+		"target/base-widget-dashboard-templates-cfg", "target/feo-context-cfg", "target/widget-registry-cfg",
+	}
+	if len(fe.Status.ConfigMaps) != len(expectedConfigMaps) {
+		t.Fatalf("expected config maps %v, got %v", expectedConfigMaps, fe.Status.ConfigMaps)
+	}
+	for i, name := range expectedConfigMaps {
+		cm := fe.Status.ConfigMaps[i]
+		if cm.Namespace+"/"+cm.Name != name {
+			t.Errorf("expected config map %s at index %d, got %s/%s", name, i, cm.Namespace, cm.Name)
+		}
+		if cm.Hash == "" {
+			t.Errorf("expected a hash for config map %s", name)
+		}
+	}
+
+	if !meta.IsStatusConditionTrue(fe.Status.Conditions, crd.ConfigGenerated) {
+		t.Errorf("expected ConfigGenerated to be true, got %v", fe.Status.Conditions)
+	}
+	if meta.FindStatusCondition(fe.Status.Conditions, crd.ReverseProxyReady) != nil {
+		t.Errorf("expected no ReverseProxyReady condition without push cache, got %v", fe.Status.Conditions)
+	}
+}
+
+func TestPopulateFrontendEnvironmentStatusMissingConfigMaps(t *testing.T) {
+	fe := &crd.FrontendEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "env"},
+		Spec:       crd.FrontendEnvironmentSpec{TargetNamespaces: []string{"target"}},
+	}
+	frontends := []crd.Frontend{statusTestFrontend("chrome", "ns-a", true, true)}
+
+	populateFrontendEnvironmentStatus(fe, frontends, nil, nil)
+
+	condition := meta.FindStatusCondition(fe.Status.Conditions, crd.ConfigGenerated)
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != "ConfigMapsMissing" {
+		t.Fatalf("expected ConfigGenerated to be false with ConfigMapsMissing, got %v", condition)
+	}
+	if condition.Message != "Missing generated config maps: ns-a/env, ns-a/env-base-widget-dashboard-templates, ns-a/env-widget-registry, "+
+		"target/base-widget-dashboard-templates-cfg, target/feo-context-cfg, target/widget-registry-cfg" {
+		t.Errorf("unexpected message %q", condition.Message)
+	}
+
+	populateFrontendEnvironmentStatus(fe, nil, nil, nil)
+
+	condition = meta.FindStatusCondition(fe.Status.Conditions, crd.ConfigGenerated)
+	if condition == nil || condition.Reason != "NoFrontends" {
+		t.Errorf("expected NoFrontends once all frontends are gone, got %v", condition)
+	}
+	if fe.Status.Frontends != 0 || fe.Status.FeoConfigEnabledFrontends != nil {
+		t.Errorf("expected the frontend counts to be reset, got %+v", fe.Status)
+	}
+}
+
+func TestPopulateFrontendEnvironmentStatusContextCopies(t *testing.T) {
+	fe := &crd.FrontendEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "env"},
+		Spec:       crd.FrontendEnvironmentSpec{TargetNamespaces: []string{"target"}},
+	}
+	frontends := []crd.Frontend{statusTestFrontend("chrome", "ns-beta", true, true)}
+	configMaps := []v1.ConfigMap{
+		statusTestConfigMap("env", "ns-beta"),
+		statusTestConfigMap("env-widget-registry", "ns-beta"),
+		statusTestConfigMap("env-base-widget-dashboard-templates", "ns-beta"),
+		statusTestConfigMap("feo-context-cfg-beta", "target"),
+		statusTestConfigMap("widget-registry-cfg", "target"),
+		statusTestConfigMap("base-widget-dashboard-templates-cfg", "target"),
+	}
+
+	populateFrontendEnvironmentStatus(fe, frontends, configMaps, nil)
+	if !meta.IsStatusConditionTrue(fe.Status.Conditions, crd.ConfigGenerated) {
+		t.Errorf("expected ConfigGenerated to be true with the beta context copies, got %v", fe.Status.Conditions)
+	}
+
+	populateFrontendEnvironmentStatus(fe, frontends, configMaps[:4], nil)
+	condition := meta.FindStatusCondition(fe.Status.Conditions, crd.ConfigGenerated)
+	if condition == nil || condition.Message != "Missing generated config maps: target/base-widget-dashboard-templates-cfg, target/widget-registry-cfg" {
+		t.Errorf("expected the widget context copies to be missing, got %v", condition)
+	}
+}
+
+func TestPopulateFrontendEnvironmentStatusReverseProxy(t *testing.T) {
+	fe := &crd.FrontendEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "env"},
+		Spec: crd.FrontendEnvironmentSpec{
+			EnablePushCache:   true,
+			ReverseProxyImage: "quay.io/redhat-services-prod/reverse-proxy:latest",
+		},
+	}
+
+	populateFrontendEnvironmentStatus(fe, nil, nil, nil)
+	condition := meta.FindStatusCondition(fe.Status.Conditions, crd.ReverseProxyReady)
+	if condition == nil || condition.Reason != "DeploymentNotFound" {
+		t.Fatalf("expected DeploymentNotFound, got %v", condition)
+	}
+
+	proxy := &apps.Deployment{
+		Status: apps.DeploymentStatus{
+			Replicas:      2,
+			ReadyReplicas: 1,
+			Conditions: []apps.DeploymentCondition{{
+				Type:   apps.DeploymentAvailable,
+				Status: v1.ConditionTrue,
+			}},
+		},
+	}
+	populateFrontendEnvironmentStatus(fe, nil, nil, proxy)
+	if !meta.IsStatusConditionTrue(fe.Status.Conditions, crd.ReverseProxyReady) {
+		t.Errorf("expected ReverseProxyReady to be true, got %v", fe.Status.Conditions)
+	}
+
+	fe.Spec.EnablePushCache = false
+	populateFrontendEnvironmentStatus(fe, nil, nil, proxy)
+	if meta.FindStatusCondition(fe.Status.Conditions, crd.ReverseProxyReady) != nil {
+		t.Errorf("expected ReverseProxyReady to be removed once push cache is disabled")
+	}
+}
@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCreateCaddyfileHeaderPolicy(t *testing.T) {
+	if headerPolicy := createCaddyfileHeaderPolicy(nil, nil, nil); headerPolicy != "" {
+		t.Errorf("expected no header policy, got %q", headerPolicy)
+	}
+
+	headerPolicy := createCaddyfileHeaderPolicy(
+		map[string]string{"X-Frame-Options": "DENY"},
+		[]crd.HeaderPolicy{
+			{Path: "*.js", Headers: map[string]string{"Cache-Control": "public, max-age=31536000, immutable"}},
+			{Path: "fed-mods.json", Headers: map[string]string{"Cache-Control": "no-cache", "ETag": ""}},
+		},
+		[]crd.HeaderPolicy{
+			{Path: "/apps/landing/index.html", Headers: map[string]string{"Content-Security-Policy": `default-src 'self'; report-uri "/csp"`, "x-frame-options": ""}},
+			{Path: "*.js", Headers: map[string]string{"Cache-Control": "max-age=300", "Server": ""}},
+			{Path: "*.css", Headers: map[string]string{"X-Frame-Options": ""}},
+		},
+	)
+
+	expected := `header {
+	X-Frame-Options "DENY"
+}
+@header_policy_0 path *.js
+header @header_policy_0 {
+	Cache-Control "public, max-age=31536000, immutable"
+}
+@header_policy_1 path */fed-mods.json
+header @header_policy_1 {
+	Cache-Control "no-cache"
+	-ETag
+}
+@header_policy_2 path /apps/landing/index.html
+header @header_policy_2 {
+	?Content-Security-Policy "default-src 'self'; report-uri \"/csp\""
+}
+@header_policy_3 path *.js
+header @header_policy_3 {
+	?Cache-Control "max-age=300"
+	-Server
+}
+`
+	if headerPolicy != expected {
+		t.Errorf("unexpected header policy:\n%s\nexpected:\n%s", headerPolicy, expected)
+	}
+}
+
+func TestFrontendHeaderPolicyEnvVar(t *testing.T) {
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend, frontendEnvironment).Build()
+	nn := types.NamespacedName{Name: "landing-frontend", Namespace: "boot"}
+
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	d := &apps.Deployment{}
+	if err := pClient.Get(context.Background(), nn, d); err != nil {
+		t.Fatal(err)
+	}
+	for _, envVar := range d.Spec.Template.Spec.Containers[0].Env {
+		if envVar.Name == "CADDY_HEADER_POLICY" {
+			t.Errorf("expected no header policy without headers, got %q", envVar.Value)
+		}
+	}
+
+	frontend.Spec.HeaderPolicies = []crd.HeaderPolicy{{Path: "*.js", Headers: map[string]string{"Cache-Control": "no-cache"}}}
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	if err := pClient.Get(context.Background(), nn, d); err != nil {
+		t.Fatal(err)
+	}
+	expected := "@header_policy_0 path *.js\nheader @header_policy_0 {\n\t?Cache-Control \"no-cache\"\n}\n"
+	found := false
+	for _, envVar := range d.Spec.Template.Spec.Containers[0].Env {
+		if envVar.Name == "CADDY_HEADER_POLICY" {
+			found = true
+			if envVar.Value != expected {
+				t.Errorf("unexpected header policy %q", envVar.Value)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected the CADDY_HEADER_POLICY env var")
+	}
+}
+
+func TestFrontendHTTPHeadersEnvVar(t *testing.T) {
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	frontendEnvironment.Spec.HTTPHeaders = map[string]string{"X-Frame-Options": "DENY"}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend, frontendEnvironment).Build()
+
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	d := &apps.Deployment{}
+	if err := pClient.Get(context.Background(), types.NamespacedName{Name: "landing-frontend", Namespace: "boot"}, d); err != nil {
+		t.Fatal(err)
+	}
+	headerEnvVars := map[string]string{}
+	for _, envVar := range d.Spec.Template.Spec.Containers[0].Env {
+		if strings.HasPrefix(envVar.Name, "CADDY_HEADER") || strings.HasPrefix(envVar.Name, "CADDY_HTTP") {
+			headerEnvVars[envVar.Name] = envVar.Value
+		}
+	}
+	expected := map[string]string{
+		"CADDY_HTTP_HEADERS":  "header {\nX-Frame-Options DENY\n}",
+		"CADDY_HEADER_POLICY": "header {\n\tX-Frame-Options \"DENY\"\n}\n",
+	}
+	if !reflect.DeepEqual(headerEnvVars, expected) {
+		t.Errorf("expected the enforced httpHeaders in both env vars, got %v", headerEnvVars)
+	}
+}
@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	"github.com/go-logr/logr"
+	networking "k8s.io/api/networking/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestFrontendHTTPRoute(t *testing.T) {
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	frontendEnvironment.Spec.HTTPHeaders = map[string]string{"X-Frame-Options": "DENY", "Cache-Control": "no-cache"}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend, frontendEnvironment).Build()
+	nn := types.NamespacedName{Name: "landing", Namespace: "boot"}
+
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	if err := pClient.Get(context.Background(), nn, &networking.Ingress{}); err != nil {
+		t.Fatalf("expected the ingress to be created: %v", err)
+	}
+
+	frontendEnvironment.Spec.RoutingMode = crd.RoutingModeGatewayAPI
+	frontendEnvironment.Spec.Gateway = &crd.GatewayParentRef{Name: "public", Namespace: "gateways", SectionName: "https"}
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	route := &gatewayv1.HTTPRoute{}
+	if err := pClient.Get(context.Background(), nn, route); err != nil {
+		t.Fatalf("expected the HTTPRoute to be created: %v", err)
+	}
+	parentRef := route.Spec.ParentRefs[0]
+	if parentRef.Name != "public" || *parentRef.Namespace != "gateways" || *parentRef.SectionName != "https" {
+		t.Errorf("expected the route to attach to the gateway, got %v", parentRef)
+	}
+	if len(route.Spec.Hostnames) != 1 || route.Spec.Hostnames[0] != "stage.example.com" {
+		t.Errorf("expected the environment hostname, got %v", route.Spec.Hostnames)
+	}
+	rule := route.Spec.Rules[0]
+	if len(rule.Matches) != 1 || *rule.Matches[0].Path.Value != "/apps/landing" || *rule.Matches[0].Path.Type != gatewayv1.PathMatchPathPrefix {
+		t.Errorf("expected a prefix match for the frontend path, got %v", rule.Matches)
+	}
+	if rule.BackendRefs[0].Name != "landing" || *rule.BackendRefs[0].Port != 8000 {
+		t.Errorf("expected the frontend service backend, got %v", rule.BackendRefs[0])
+	}
+	if len(rule.Filters) != 1 || rule.Filters[0].ResponseHeaderModifier == nil {
+		t.Fatalf("expected a response header filter, got %v", rule.Filters)
+	}
+	headers := rule.Filters[0].ResponseHeaderModifier.Set
+	if len(headers) != 2 || headers[0].Name != "Cache-Control" || headers[1].Value != "DENY" {
+		t.Errorf("expected the sorted environment headers, got %v", headers)
+	}
+	if len(route.OwnerReferences) != 1 || route.OwnerReferences[0].Name != "landing" {
+		t.Errorf("expected the route to be owned by the frontend, got %v", route.OwnerReferences)
+	}
+	if err := pClient.Get(context.Background(), nn, &networking.Ingress{}); !k8serr.IsNotFound(err) {
+		t.Errorf("expected the ingress to be removed, got %v", err)
+	}
+
+	frontendEnvironment.Spec.RoutingMode = crd.RoutingModeIngress
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	if err := pClient.Get(context.Background(), nn, &gatewayv1.HTTPRoute{}); !k8serr.IsNotFound(err) {
+		t.Errorf("expected the HTTPRoute to be removed, got %v", err)
+	}
+	if err := pClient.Get(context.Background(), nn, &networking.Ingress{}); err != nil {
+		t.Errorf("expected the ingress to be restored: %v", err)
+	}
+}
+
+func TestValidateGatewayRouting(t *testing.T) {
+	frontendEnvironment := &crd.FrontendEnvironment{
+		Spec: crd.FrontendEnvironmentSpec{RoutingMode: crd.RoutingModeGatewayAPI},
+	}
+	if err := validateGatewayRouting(frontendEnvironment); err == nil {
+		t.Error("expected an error without a gateway")
+	}
+
+	frontendEnvironment.Spec.Gateway = &crd.GatewayParentRef{Name: "public"}
+	if err := validateGatewayRouting(frontendEnvironment); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+}
+
+func TestGatewayRoutingWithWhitelistKeepsIngress(t *testing.T) {
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	frontendEnvironment.Spec.RoutingMode = crd.RoutingModeGatewayAPI
+	frontendEnvironment.Spec.Gateway = &crd.GatewayParentRef{Name: "public"}
+	frontendEnvironment.Spec.Whitelist = []string{"10.0.0.0/8"}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend, frontendEnvironment).Build()
+
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	nn := types.NamespacedName{Name: frontend.Name, Namespace: frontend.Namespace}
+	ingress := &networking.Ingress{}
+	if err := pClient.Get(context.Background(), nn, ingress); err != nil {
+		t.Fatalf("expected the ingress to be kept: %v", err)
+	}
+	if ingress.Annotations["nginx.ingress.kubernetes.io/whitelist-source-range"] != "10.0.0.0/8" {
+		t.Errorf("expected the whitelist on the ingress, got %v", ingress.Annotations)
+	}
+	if err := pClient.Get(context.Background(), nn, &gatewayv1.HTTPRoute{}); !k8serr.IsNotFound(err) {
+		t.Errorf("expected no HTTPRoute, got %v", err)
+	}
+
+	populateFrontendEnvironmentStatus(frontendEnvironment, []crd.Frontend{*frontend}, nil, nil)
+	condition := meta.FindStatusCondition(frontendEnvironment.Status.Conditions, crd.RoutingModeApplied)
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != "WhitelistNotSupported" {
+		t.Errorf("expected the RoutingModeApplied condition to be false, got %v", condition)
+	}
+
+	frontendEnvironment.Spec.Whitelist = nil
+	populateFrontendEnvironmentStatus(frontendEnvironment, []crd.Frontend{*frontend}, nil, nil)
+	if meta.FindStatusCondition(frontendEnvironment.Status.Conditions, crd.RoutingModeApplied) != nil {
+		t.Error("expected the RoutingModeApplied condition to be removed")
+	}
+}
+
+func TestReverseProxyHTTPRoute(t *testing.T) {
+	frontendEnvironment := &crd.FrontendEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "stage", UID: "stage-uid"},
+		Spec: crd.FrontendEnvironmentSpec{
+			ReverseProxyHostname: "proxy.example.com",
+		},
+	}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciliation := &ReverseProxyReconciliation{
+		Log:                 logr.Discard(),
+		Client:              pClient,
+		Ctx:                 context.Background(),
+		Namespace:           "stage",
+		FrontendEnvironment: frontendEnvironment,
+	}
+	nn := types.NamespacedName{Name: "reverse-proxy", Namespace: "stage"}
+
+	if err := reconciliation.reconcileIngress(); err != nil {
+		t.Fatal(err)
+	}
+
+	frontendEnvironment.Spec.RoutingMode = crd.RoutingModeGatewayAPI
+	frontendEnvironment.Spec.Gateway = &crd.GatewayParentRef{Name: "public"}
+	if err := reconciliation.reconcileHTTPRoute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := reconciliation.deleteReverseProxyIngress(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	route := &gatewayv1.HTTPRoute{}
+	if err := pClient.Get(context.Background(), nn, route); err != nil {
+		t.Fatalf("expected the HTTPRoute to be created: %v", err)
+	}
+	rule := route.Spec.Rules[0]
+	if route.Spec.Hostnames[0] != "proxy.example.com" || *rule.Matches[0].Path.Value != "/" || *rule.BackendRefs[0].Port != ReverseProxyPort {
+		t.Errorf("expected the reverse proxy route, got %v", route.Spec)
+	}
+	if len(rule.Filters) != 0 {
+		t.Errorf("expected no header filters on the reverse proxy route, got %v", rule.Filters)
+	}
+	if err := pClient.Get(context.Background(), nn, &networking.Ingress{}); !k8serr.IsNotFound(err) {
+		t.Errorf("expected the ingress to be removed, got %v", err)
+	}
+
+	// An unchanged route is not updated again
+	resourceVersion := route.ResourceVersion
+	if err := reconciliation.reconcileHTTPRoute(); err != nil {
+		t.Fatal(err)
+	}
+	if err := pClient.Get(context.Background(), nn, route); err != nil {
+		t.Fatal(err)
+	}
+	if route.ResourceVersion != resourceVersion {
+		t.Errorf("expected the unchanged route to be left alone")
+	}
+
+	if err := deleteOwnedHTTPRoute(context.Background(), pClient, nn, frontendEnvironment.UID); err != nil {
+		t.Fatal(err)
+	}
+	if err := pClient.Get(context.Background(), nn, &gatewayv1.HTTPRoute{}); !k8serr.IsNotFound(err) {
+		t.Errorf("expected the HTTPRoute to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	"github.com/RedHatInsights/frontend-operator/controllers/registry"
+)
+
+const (
+	// imageResolutionRetryInterval is the wait before a failed resolution is tried again
+	imageResolutionRetryInterval = time.Minute
+
+	defaultImageMessageLimit = 1024
+)
+
+// imageResolutionEnabled returns true when the environment pins the digests of the images
+func imageResolutionEnabled(frontendEnvironment *crd.FrontendEnvironment) bool {
+	return frontendEnvironment.Spec.ImageResolution != nil && frontendEnvironment.Spec.ImageResolution.Enabled
+}
+
+// getImagePollInterval returns the interval the tags are resolved again at, 0 when they are
+// only resolved for a new image
+func getImagePollInterval(frontendEnvironment *crd.FrontendEnvironment) time.Duration {
+	if frontendEnvironment.Spec.ImageResolution == nil || frontendEnvironment.Spec.ImageResolution.PollInterval == nil {
+		return 0
+	}
+	return frontendEnvironment.Spec.ImageResolution.PollInterval.Duration
+}
+
+// getFrontendImage returns the image the Deployment and the push cache Job run, the image of
+// the spec pinned to its digest once it is resolved
+func (r *FrontendReconciliation) getFrontendImage() string {
+	status := r.Frontend.Status.Image
+	if !imageResolutionEnabled(r.FrontendEnvironment) || status == nil || status.Image != r.Frontend.Spec.Image || status.ResolvedImage == "" {
+		return r.Frontend.Spec.Image
+	}
+	return status.ResolvedImage
+}
+
+func (r *FrontendReconciliation) newResolver(config registry.Config) (registry.Resolver, error) {
+	if r.NewResolver != nil {
+		return r.NewResolver(config)
+	}
+	return registry.NewResolver(config)
+}
+
+// resolveImage resolves the image with the registry credentials of the environment
+func (r *FrontendReconciliation) resolveImage(image string) (string, error) {
+	config := registry.Config{}
+	if secretName := r.FrontendEnvironment.Spec.ImageResolution.PullSecretName; secretName != "" {
+		secret := &v1.Secret{}
+		nn := types.NamespacedName{Name: secretName, Namespace: r.Frontend.Namespace}
+		if err := r.Client.Get(r.Ctx, nn, secret); err != nil {
+			return "", fmt.Errorf("could not get the registry credentials %s: %w", secretName, err)
+		}
+		config.Credentials = secret.Data
+	}
+	resolver, err := r.newResolver(config)
+	if err != nil {
+		return "", err
+	}
+	return resolver.Resolve(r.Ctx, image)
+}
+
+// reconcileImageDigest resolves the image of the Frontend to a digest when the image changes,
+// and on the poll interval of the environment to follow a tag that moves. A failed resolution
+// keeps the last digest of the image pinned, or the tag when it never resolved.
+func (r *FrontendReconciliation) reconcileImageDigest() error {
+	image := r.Frontend.Spec.Image
+	pollInterval := getImagePollInterval(r.FrontendEnvironment)
+	previous := r.Frontend.Status.Image
+	current := previous != nil && previous.Image == image && previous.Digest != ""
+	if current && previous.Message == "" {
+		if pollInterval <= 0 {
+			return nil
+		}
+		if wait := time.Until(previous.ResolveTime.Add(pollInterval)); wait > 0 {
+			r.requeueAfter(wait)
+			return nil
+		}
+	}
+
+	status := &crd.ImageStatus{Image: image}
+	if current {
+		status = previous.DeepCopy()
+	} else if previous != nil && previous.Image == image {
+		// The image never resolved, the failure is reported again
+		status.Message = previous.Message
+	}
+
+	digest, err := r.resolveImage(image)
+	if err != nil {
+		message := truncateMessage(err.Error(), defaultImageMessageLimit)
+		if status.Message != message {
+			r.recordEvent(v1.EventTypeWarning, "ImageResolutionFailed", "Could not resolve %s: %s", image, message)
+		}
+		status.Message = message
+		r.requeueAfter(imageResolutionRetryInterval)
+		return r.setImageStatus(status)
+	}
+
+	if current && previous.Digest != digest {
+		r.recordEvent(v1.EventTypeNormal, "ImageTagMoved", "%s moved from %s to %s", image, previous.Digest, digest)
+	}
+	now := metav1.Now()
+	status.Digest = digest
+	status.ResolvedImage = registry.PinDigest(image, digest)
+	status.ResolveTime = &now
+	status.Message = ""
+	if pollInterval > 0 {
+		r.requeueAfter(pollInterval)
+	}
+	return r.setImageStatus(status)
+}
+
+// setImageStatus persists the status right away, the pinned image is read back from it by the
+// following reconciliations
+func (r *FrontendReconciliation) setImageStatus(status *crd.ImageStatus) error {
+	nn := types.NamespacedName{Name: r.Frontend.Name, Namespace: r.Frontend.Namespace}
+	if err := SetFrontendImageStatus(r.Ctx, r.Client, nn, status); err != nil {
+		return err
+	}
+	r.Frontend.Status.Image = status
+	return nil
+}
@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	"github.com/RedHatInsights/frontend-operator/controllers/registry"
+	apps "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeResolver struct {
+	digests map[string]string
+	err     error
+	images  []string
+}
+
+func (r *fakeResolver) Resolve(_ context.Context, image string) (string, error) {
+	r.images = append(r.images, image)
+	if r.err != nil {
+		return "", r.err
+	}
+	return r.digests[image], nil
+}
+
+func TestReconcileImageDigest(t *testing.T) {
+	setPushCacheEnv(t)
+	ctx := context.Background()
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	frontendEnvironment.Spec.EnablePushCache = true
+	frontendEnvironment.Spec.ValpopImage = "quay.io/valpop:1"
+	frontendEnvironment.Spec.ImageResolution = &crd.ImageResolutionConfig{
+		Enabled:        true,
+		PollInterval:   &metav1.Duration{Duration: 10 * time.Minute},
+		PullSecretName: "quay-pull",
+	}
+	pullSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "quay-pull", Namespace: "boot"},
+		Type:       v1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{".dockerconfigjson": []byte(`{"auths":{}}`)},
+	}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend, pullSecret).WithStatusSubresource(&crd.Frontend{}).Build()
+	resolver := &fakeResolver{digests: map[string]string{"quay.io/landing:abc": "sha256:01"}}
+	var resolverConfig registry.Config
+	recorder := record.NewFakeRecorder(10)
+	reconcileImage := func() *FrontendReconciliation {
+		t.Helper()
+		r := &FrontendReconciliation{
+			Ctx:                 ctx,
+			Client:              pClient,
+			Recorder:            recorder,
+			Frontend:            frontend,
+			FrontendEnvironment: frontendEnvironment,
+			NewResolver: func(config registry.Config) (registry.Resolver, error) {
+				resolverConfig = config
+				return resolver, nil
+			},
+		}
+		if err := r.reconcileImageDigest(); err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	storedStatus := func() *crd.ImageStatus {
+		t.Helper()
+		stored := &crd.Frontend{}
+		if err := pClient.Get(ctx, types.NamespacedName{Name: "landing", Namespace: "boot"}, stored); err != nil {
+			t.Fatal(err)
+		}
+		return stored.Status.Image
+	}
+	getJob := func() *batchv1.Job {
+		t.Helper()
+		job := &batchv1.Job{}
+		if err := pClient.Get(ctx, types.NamespacedName{Name: "landing-frontend-pushcache", Namespace: "boot"}, job); err != nil {
+			t.Fatal(err)
+		}
+		return job
+	}
+	getDeploymentImage := func() string {
+		t.Helper()
+		d := &apps.Deployment{}
+		if err := pClient.Get(ctx, types.NamespacedName{Name: "landing-frontend", Namespace: "boot"}, d); err != nil {
+			t.Fatal(err)
+		}
+		return d.Spec.Template.Spec.Containers[0].Image
+	}
+
+	r := reconcileImage()
+	status := storedStatus()
+	if status == nil || status.Digest != "sha256:01" || status.ResolvedImage != "quay.io/landing:abc@sha256:01" || status.ResolveTime == nil {
+		t.Fatalf("expected the resolved digest, got %+v", status)
+	}
+	if r.RequeueAfter != 10*time.Minute {
+		t.Errorf("expected the tag to be polled after 10m, got %s", r.RequeueAfter)
+	}
+	if string(resolverConfig.Credentials[".dockerconfigjson"]) != `{"auths":{}}` {
+		t.Errorf("expected the credentials of the pull secret, got %v", resolverConfig.Credentials)
+	}
+
+	// The Deployment and the push cache Job run the pinned image
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	if image := getDeploymentImage(); image != "quay.io/landing:abc@sha256:01" {
+		t.Errorf("expected the Deployment to run the pinned image, got %s", image)
+	}
+	job := getJob()
+	if job.Spec.Template.Spec.InitContainers[0].Image != "quay.io/landing:abc@sha256:01" || job.Spec.Template.Annotations["frontend-image"] != "quay.io/landing:abc@sha256:01" {
+		t.Errorf("expected the push cache to publish the pinned image, got %s", job.Spec.Template.Spec.InitContainers[0].Image)
+	}
+
+	// The tag is not resolved again before the poll interval
+	reconcileImage()
+	if len(resolver.images) != 1 {
+		t.Errorf("expected no resolution before the poll interval, got %v", resolver.images)
+	}
+
+	// A moved tag rolls the Deployment out and reruns the push cache
+	resolveTime := metav1.NewTime(time.Now().Add(-time.Hour))
+	frontend.Status.Image.ResolveTime = &resolveTime
+	resolver.digests["quay.io/landing:abc"] = "sha256:02"
+	reconcileImage()
+	if event := <-recorder.Events; !strings.HasPrefix(event, "Normal ImageTagMoved") {
+		t.Errorf("expected the moved tag to be reported, got %q", event)
+	}
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	if image := getDeploymentImage(); image != "quay.io/landing:abc@sha256:02" {
+		t.Errorf("expected the Deployment to run the new digest, got %s", image)
+	}
+	if image := getJob().Spec.Template.Annotations["frontend-image"]; image != "quay.io/landing:abc@sha256:02" {
+		t.Errorf("expected the push cache job to be recreated for the new digest, got %s", image)
+	}
+
+	// A failed resolution keeps the last digest pinned
+	frontend.Status.Image.ResolveTime = &resolveTime
+	resolver.err = errors.New("resolving quay.io/landing:abc failed with 503")
+	r = reconcileImage()
+	status = storedStatus()
+	if status.Digest != "sha256:02" || !strings.Contains(status.Message, "503") {
+		t.Errorf("expected the last digest and the failure, got %+v", status)
+	}
+	if r.RequeueAfter != imageResolutionRetryInterval {
+		t.Errorf("expected a retry after %s, got %s", imageResolutionRetryInterval, r.RequeueAfter)
+	}
+	if event := <-recorder.Events; !strings.HasPrefix(event, "Warning ImageResolutionFailed") {
+		t.Errorf("expected the failure to be reported, got %q", event)
+	}
+	if image := r.getFrontendImage(); image != "quay.io/landing:abc@sha256:02" {
+		t.Errorf("expected the last digest to stay pinned, got %s", image)
+	}
+
+	// A new image is not pinned before it resolves
+	frontend.Spec.Image = "quay.io/landing:def"
+	if image := r.getFrontendImage(); image != "quay.io/landing:def" {
+		t.Errorf("expected the tag of the new image, got %s", image)
+	}
+}
@@ -30,6 +30,29 @@ var (
 		},
 		[]string{"app"},
 	)
+	pushCacheDurationMetric = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "frontend_app_pushcache_duration_seconds",
+			Help: "Frontend App push cache job duration",
+			// From 10 seconds to about 6 hours
+			Buckets: prometheus.ExponentialBuckets(10, 2, 12),
+		},
+		[]string{"app", "outcome"},
+	)
+	pushCacheFailuresMetric = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "frontend_app_pushcache_failures",
+			Help: "Frontend App push cache job failures",
+		},
+		[]string{"app"},
+	)
+	rolloutsMetric = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "frontend_app_canary_rollouts",
+			Help: "Frontend App canary rollouts",
+		},
+		[]string{"app", "outcome"},
+	)
 )
 
 func init() {
@@ -38,5 +61,8 @@ func init() {
 		managedFrontendsMetric,
 		reconciliationRequestMetric,
 		reconciliationTimeMetrics,
+		pushCacheDurationMetric,
+		pushCacheFailuresMetric,
+		rolloutsMetric,
 	)
 }
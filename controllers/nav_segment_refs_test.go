@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func navSegment(segmentID string, navItems ...crd.ChromeNavItem) *crd.NavigationSegment {
+	return &crd.NavigationSegment{SegmentID: segmentID, NavItems: &navItems}
+}
+
+func segmentRefItem(frontendName, segmentID string) crd.ChromeNavItem {
+	return crd.ChromeNavItem{SegmentRef: &crd.SegmentRef{FrontendName: frontendName, SegmentID: segmentID}}
+}
+
+func navRefsTestFrontends() *crd.FrontendList {
+	return &crd.FrontendList{Items: []crd.Frontend{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "landing", Namespace: "boot"},
+			Spec: crd.FrontendSpec{
+				FeoConfigEnabled: true,
+				BundleSegments: []*crd.BundleSegment{{
+					SegmentID: "landing-nav",
+					BundleID:  "insights",
+					NavItems: &[]crd.ChromeNavItem{
+						{Title: "Home", Href: "/insights"},
+						segmentRefItem("inventory", "hosts"),
+						segmentRefItem("missing", "anything"),
+					},
+				}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "inventory", Namespace: "inventory-ns"},
+			Spec: crd.FrontendSpec{
+				FeoConfigEnabled: true,
+				NavigationSegments: []*crd.NavigationSegment{
+					navSegment("hosts",
+						crd.ChromeNavItem{Title: "Hosts", Href: "/insights/inventory"},
+						segmentRefItem("groups", "all"),
+						segmentRefItem("inventory", "unknown"),
+					),
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "groups", Namespace: "inventory-ns"},
+			Spec: crd.FrontendSpec{
+				FeoConfigEnabled: true,
+				NavigationSegments: []*crd.NavigationSegment{
+					navSegment("all",
+						crd.ChromeNavItem{Title: "Groups", Href: "/insights/inventory/groups"},
+						segmentRefItem("inventory", "hosts"),
+					),
+				},
+			},
+		},
+	}}
+}
+
+func TestSetupBundlesDataReportsUnresolvedSegmentRefs(t *testing.T) {
+	feEnvironment := crd.FrontendEnvironment{
+		Spec: crd.FrontendEnvironmentSpec{
+			Bundles: &[]crd.FrontendBundles{{ID: "insights", Title: "Insights"}},
+		},
+	}
+
+	bundles, _, issues, err := setupBundlesData(navRefsTestFrontends(), feEnvironment)
+	if err != nil {
+		t.Fatalf("expected the cycle to be reported instead of failing, got %v", err)
+	}
+
+	expected := map[string]string{
+		`segment reference inventory/hosts: reference cycle inventory/hosts → groups/all → inventory/hosts`: "inventory-ns/groups",
+		`segment reference inventory/unknown: frontend "inventory" has no navigation segment "unknown"`:     "inventory-ns/inventory",
+		`segment reference missing/anything: frontend "missing" has no navigation segments`:                 "boot/landing",
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("expected %d issues, got %v", len(expected), issues)
+	}
+	for _, issue := range issues {
+		frontend, ok := expected[issue.String()]
+		if !ok {
+			t.Errorf("unexpected issue %q", issue.String())
+			continue
+		}
+		if issue.FrontendNamespace+"/"+issue.FrontendName != frontend {
+			t.Errorf("expected %q to be reported on %s, got %s/%s", issue.String(), frontend, issue.FrontendNamespace, issue.FrontendName)
+		}
+	}
+
+	if len(bundles) != 1 {
+		t.Fatalf("expected the bundle to be generated, got %v", bundles)
+	}
+	titles := []string{}
+	for _, navItem := range bundles[0].NavItems {
+		titles = append(titles, navItem.Title)
+	}
+	if strings.Join(titles, ",") != "Home,Hosts,Groups" {
+		t.Errorf("expected the resolvable nav items to be generated, got %v", titles)
+	}
+}
+
+func TestNavigationReferencesCondition(t *testing.T) {
+	condition := NavigationReferencesCondition([]string{})
+	if condition.Status != metav1.ConditionTrue || condition.Type != crd.NavigationReferencesResolved {
+		t.Errorf("expected a true NavigationReferencesResolved condition, got %v", condition)
+	}
+
+	condition = NavigationReferencesCondition([]string{"segment reference a/b: reference cycle a/b → a/b"})
+	if condition.Status != metav1.ConditionFalse || condition.Reason != "UnresolvedReferences" {
+		t.Errorf("expected a false condition with unresolved references, got %v", condition)
+	}
+	if !strings.Contains(condition.Message, "a/b → a/b") {
+		t.Errorf("expected the message to contain the reference chain, got %q", condition.Message)
+	}
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2025 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+)
+
+// Keys of the object store credentials, in the credentialsSecretRef of the environment and
+// in the Secrets managed by the operator
+const (
+	objectStoreAccessKeyIDKey     = "aws_access_key_id"
+	objectStoreSecretAccessKeyKey = "aws_secret_access_key"
+)
+
+// ObjectStoreBucket represents the configuration for an object storage bucket.
+// The credentials of the bucket are read by getObjectStoreCredentials.
+type ObjectStoreBucket struct {
+	Name       *string
+	Region     *string
+	Endpoint   *string
+	Port       *string
+	TLS        *bool
+	PathPrefix *string
+}
+
+// objectStoreSetting returns the value of the environment when it is set and of the operator
+// environment variable otherwise
+func objectStoreSetting(value, envVar string) (string, error) {
+	if value != "" {
+		return value, nil
+	}
+	value = os.Getenv(envVar)
+	if value == "" {
+		return "", fmt.Errorf("required environment variable %s is not set and the objectStore of the environment does not set it either", envVar)
+	}
+	return value, nil
+}
+
+// ExtractBucketConfig extracts the ObjectStoreBucket configuration of the environment, the
+// settings it leaves out are read from the environment variables of the operator
+func ExtractBucketConfig(frontendEnvironment *crd.FrontendEnvironment) (*ObjectStoreBucket, error) {
+	objectStore := &crd.ObjectStoreConfig{}
+	if frontendEnvironment.Spec.ObjectStore != nil {
+		objectStore = frontendEnvironment.Spec.ObjectStore
+	}
+
+	bucketName, err := objectStoreSetting(objectStore.Bucket, "PUSHCACHE_AWS_BUCKET_NAME")
+	if err != nil {
+		return nil, err
+	}
+	region, err := objectStoreSetting(objectStore.Region, "PUSHCACHE_AWS_REGION")
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := objectStoreSetting(objectStore.Endpoint, "PUSHCACHE_AWS_ENDPOINT")
+	if err != nil {
+		return nil, err
+	}
+
+	port := os.Getenv("PUSHCACHE_AWS_PORT")
+	if objectStore.Port != nil {
+		port = strconv.Itoa(int(*objectStore.Port))
+	}
+	// Default Objectstore Port to "443" if not provided
+	if port == "" {
+		port = "443"
+	}
+
+	// TLS is assumed on the standard HTTPS port and plain HTTP elsewhere (local development)
+	tls := port == "443"
+	if objectStore.TLS != nil {
+		tls = *objectStore.TLS
+	}
+
+	return &ObjectStoreBucket{
+		Name:       &bucketName,
+		Region:     &region,
+		Endpoint:   &endpoint,
+		Port:       &port,
+		TLS:        &tls,
+		PathPrefix: utils.StringPtr(objectStore.PathPrefix),
+	}, nil
+}
+
+// KeyPrefix returns the prefix valpop publishes the assets of a frontend under
+func (b *ObjectStoreBucket) KeyPrefix(name string) string {
+	if b.PathPrefix == nil || *b.PathPrefix == "" {
+		return name
+	}
+	return *b.PathPrefix + "/" + name
+}
+
+// BucketPath returns the bucket and path prefix the reverse proxy serves the assets from
+func (b *ObjectStoreBucket) BucketPath() string {
+	if b.PathPrefix == nil || *b.PathPrefix == "" {
+		return *b.Name
+	}
+	return *b.Name + "/" + *b.PathPrefix
+}
+
+// Location identifies where the assets are published, the push cache jobs are recreated when
+// it changes
+func (b *ObjectStoreBucket) Location() string {
+	return fmt.Sprintf("%s:%s/%s", *b.Endpoint, *b.Port, b.BucketPath())
+}
+
+// getObjectStoreCredentialsSecretName returns the name of the Secret the operator keeps the
+// object store credentials of the environment in
+func getObjectStoreCredentialsSecretName(frontendEnvironment *crd.FrontendEnvironment) string {
+	return frontendEnvironment.Name + "-pushcache-credentials"
+}
+
+// getObjectStoreCredentials reads the object store credentials from the credentialsSecretRef
+// of the environment, or from the environment variables of the operator without one
+func getObjectStoreCredentials(ctx context.Context, pClient client.Client, frontendEnvironment *crd.FrontendEnvironment) (map[string][]byte, error) {
+	if frontendEnvironment.Spec.ObjectStore == nil || frontendEnvironment.Spec.ObjectStore.CredentialsSecretRef == nil {
+		accessKeyID := os.Getenv("PUSHCACHE_AWS_ACCESS_KEY_ID")
+		if accessKeyID == "" {
+			return nil, fmt.Errorf("required environment variable PUSHCACHE_AWS_ACCESS_KEY_ID is not set")
+		}
+		secretAccessKey := os.Getenv("PUSHCACHE_AWS_SECRET_ACCESS_KEY")
+		if secretAccessKey == "" {
+			return nil, fmt.Errorf("required environment variable PUSHCACHE_AWS_SECRET_ACCESS_KEY is not set")
+		}
+		return map[string][]byte{
+			objectStoreAccessKeyIDKey:     []byte(accessKeyID),
+			objectStoreSecretAccessKeyKey: []byte(secretAccessKey),
+		}, nil
+	}
+
+	ref := frontendEnvironment.Spec.ObjectStore.CredentialsSecretRef
+	if ref.Name == "" || ref.Namespace == "" {
+		return nil, fmt.Errorf("credentialsSecretRef of the object store needs a name and a namespace")
+	}
+	secret := &v1.Secret{}
+	if err := pClient.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, secret); err != nil {
+		return nil, fmt.Errorf("could not get the object store credentials %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	credentials := map[string][]byte{}
+	for _, key := range []string{objectStoreAccessKeyIDKey, objectStoreSecretAccessKeyKey} {
+		if len(secret.Data[key]) == 0 {
+			return nil, fmt.Errorf("object store credentials %s/%s are missing %s", ref.Namespace, ref.Name, key)
+		}
+		credentials[key] = secret.Data[key]
+	}
+	return credentials, nil
+}
+
+// reconcileObjectStoreCredentials keeps the Secret with the object store credentials of the
+// environment in the namespace up to date. The pods of the namespace read the credentials
+// from it so they never show in their spec. The credentials are returned.
+func reconcileObjectStoreCredentials(ctx context.Context, pClient client.Client, frontendEnvironment *crd.FrontendEnvironment, namespace string) (map[string][]byte, error) {
+	credentials, err := getObjectStoreCredentials(ctx, pClient, frontendEnvironment)
+	if err != nil {
+		return nil, err
+	}
+
+	nn := types.NamespacedName{
+		Name:      getObjectStoreCredentialsSecretName(frontendEnvironment),
+		Namespace: namespace,
+	}
+	secret := &v1.Secret{}
+	err = pClient.Get(ctx, nn, secret)
+	if k8serr.IsNotFound(err) {
+		secret = &v1.Secret{
+			Type: v1.SecretTypeOpaque,
+			Data: credentials,
+		}
+		labeler := utils.GetCustomLabeler(frontendEnvironment.GetLabels(), nn, frontendEnvironment)
+		labeler(secret)
+		return credentials, pClient.Create(ctx, secret)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if equality.Semantic.DeepEqual(secret.Data, credentials) {
+		return credentials, nil
+	}
+	secret.Data = credentials
+	return credentials, pClient.Update(ctx, secret)
+}
+
+// hashObjectStoreCredentials hashes the credentials for the pods that have to restart to
+// read rotated ones
+func hashObjectStoreCredentials(credentials map[string][]byte) (string, error) {
+	hashBase := map[string]string{}
+	for key, value := range credentials {
+		hashBase[key] = string(value)
+	}
+	return createConfigmapHash([]map[string]string{hashBase})
+}
+
+// getObjectStoreCredentialsEnv returns the PUSHCACHE_AWS_ACCESS_KEY_ID and
+// PUSHCACHE_AWS_SECRET_ACCESS_KEY variables read from the Secret managed by the operator
+func getObjectStoreCredentialsEnv(frontendEnvironment *crd.FrontendEnvironment) []v1.EnvVar {
+	secretKeyRef := func(key string) *v1.EnvVarSource {
+		return &v1.EnvVarSource{
+			SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: getObjectStoreCredentialsSecretName(frontendEnvironment),
+				},
+				Key: key,
+			},
+		}
+	}
+	return []v1.EnvVar{
+		{
+			Name:      "PUSHCACHE_AWS_ACCESS_KEY_ID",
+			ValueFrom: secretKeyRef(objectStoreAccessKeyIDKey),
+		},
+		{
+			Name:      "PUSHCACHE_AWS_SECRET_ACCESS_KEY",
+			ValueFrom: secretKeyRef(objectStoreSecretAccessKeyKey),
+		},
+	}
+}
+
+// hasInlineObjectStoreCredentials returns true for push cache jobs created before the
+// credentials moved to the managed Secret, their spec holds the credentials
+func hasInlineObjectStoreCredentials(j *batchv1.Job) bool {
+	for _, container := range j.Spec.Template.Spec.Containers {
+		if container.Name != "valpop-pushcache" {
+			continue
+		}
+		for _, env := range container.Env {
+			if env.Name == "PUSHCACHE_AWS_SECRET_ACCESS_KEY" && env.ValueFrom != nil {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,225 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func setPushCacheEnv(t *testing.T) {
+	t.Setenv("PUSHCACHE_AWS_ACCESS_KEY_ID", "env-access-key")
+	t.Setenv("PUSHCACHE_AWS_SECRET_ACCESS_KEY", "env-secret-key")
+	t.Setenv("PUSHCACHE_AWS_BUCKET_NAME", "frontend")
+	t.Setenv("PUSHCACHE_AWS_REGION", "us-east-1")
+	t.Setenv("PUSHCACHE_AWS_ENDPOINT", "minio.example.com")
+	t.Setenv("PUSHCACHE_AWS_PORT", "9000")
+}
+
+func TestObjectStoreCredentialsSecret(t *testing.T) {
+	setPushCacheEnv(t)
+	_, frontendEnvironment := autoscalingTestObjects()
+	source := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "object-store", Namespace: "frontend-operator"},
+		Data: map[string][]byte{
+			"aws_access_key_id":     []byte("access-key"),
+			"aws_secret_access_key": []byte("secret-key"),
+		},
+	}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(source).Build()
+	ctx := context.Background()
+	managed := types.NamespacedName{Name: "stage-pushcache-credentials", Namespace: "boot"}
+
+	// Without a credentialsSecretRef the credentials of the operator are copied
+	if _, err := reconcileObjectStoreCredentials(ctx, pClient, frontendEnvironment, "boot"); err != nil {
+		t.Fatal(err)
+	}
+	secret := &v1.Secret{}
+	if err := pClient.Get(ctx, managed, secret); err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data["aws_secret_access_key"]) != "env-secret-key" {
+		t.Errorf("expected the credentials of the operator, got %q", secret.Data)
+	}
+	if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].Name != "stage" {
+		t.Errorf("expected the environment to own the secret, got %v", secret.OwnerReferences)
+	}
+
+	frontendEnvironment.Spec.ObjectStore = &crd.ObjectStoreConfig{
+		CredentialsSecretRef: &v1.SecretReference{Name: "object-store", Namespace: "frontend-operator"},
+	}
+	if _, err := reconcileObjectStoreCredentials(ctx, pClient, frontendEnvironment, "boot"); err != nil {
+		t.Fatal(err)
+	}
+	if err := pClient.Get(ctx, managed, secret); err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data["aws_access_key_id"]) != "access-key" || string(secret.Data["aws_secret_access_key"]) != "secret-key" {
+		t.Errorf("expected the credentials of the referenced secret, got %q", secret.Data)
+	}
+
+	delete(source.Data, "aws_secret_access_key")
+	if err := pClient.Update(ctx, source); err != nil {
+		t.Fatal(err)
+	}
+	_, err := reconcileObjectStoreCredentials(ctx, pClient, frontendEnvironment, "boot")
+	if err == nil || !strings.Contains(err.Error(), "missing aws_secret_access_key") {
+		t.Errorf("expected the missing key to be reported, got %v", err)
+	}
+}
+
+func TestPushCacheJobReadsCredentialsFromSecret(t *testing.T) {
+	setPushCacheEnv(t)
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	frontendEnvironment.Spec.EnablePushCache = true
+	frontendEnvironment.Spec.ValpopImage = "quay.io/valpop:1"
+
+	// A job from before the credentials moved to the Secret holds them in its command
+	legacyJob := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "landing-frontend-pushcache", Namespace: "boot"},
+		Spec: batchv1.JobSpec{
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+					"frontend-image": "quay.io/landing:abc",
+					"valpop-image":   "quay.io/valpop:1",
+				}},
+				Spec: v1.PodSpec{Containers: []v1.Container{{
+					Name:    "valpop-pushcache",
+					Command: []string{"/bin/bash", "-c", "valpop populate --username env-access-key --password env-secret-key"},
+				}}},
+			},
+		},
+	}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend, legacyJob).WithStatusSubresource(&crd.Frontend{}).Build()
+
+	// The first reconcile deletes the legacy job, the second creates its replacement
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	job := &batchv1.Job{}
+	if err := pClient.Get(context.Background(), types.NamespacedName{Name: "landing-frontend-pushcache", Namespace: "boot"}, job); err != nil {
+		t.Fatal(err)
+	}
+	container := job.Spec.Template.Spec.Containers[0]
+	if strings.Contains(container.Command[2], "env-secret-key") || strings.Contains(container.Command[2], "env-access-key") {
+		t.Errorf("expected the credentials to stay out of the command, got %q", container.Command[2])
+	}
+	if !strings.Contains(container.Command[2], `--password "$PUSHCACHE_AWS_SECRET_ACCESS_KEY"`) {
+		t.Errorf("expected the password to be read from the env, got %q", container.Command[2])
+	}
+	for _, env := range container.Env {
+		if env.Value != "" || env.ValueFrom == nil || env.ValueFrom.SecretKeyRef.Name != "stage-pushcache-credentials" {
+			t.Errorf("expected %s to be read from the managed secret, got %+v", env.Name, env)
+		}
+	}
+	if len(container.Env) != 2 {
+		t.Errorf("expected the two credentials env vars, got %+v", container.Env)
+	}
+
+	secret := &v1.Secret{}
+	if err := pClient.Get(context.Background(), types.NamespacedName{Name: "stage-pushcache-credentials", Namespace: "boot"}, secret); err != nil {
+		t.Errorf("expected the credentials secret in the frontend namespace: %v", err)
+	}
+}
+
+func TestExtractBucketConfig(t *testing.T) {
+	setPushCacheEnv(t)
+	_, frontendEnvironment := autoscalingTestObjects()
+
+	bucket, err := ExtractBucketConfig(frontendEnvironment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bucket.Location() != "minio.example.com:9000/frontend" || *bucket.TLS {
+		t.Errorf("expected the operator settings without TLS, got %s tls=%v", bucket.Location(), *bucket.TLS)
+	}
+	if bucket.KeyPrefix("landing") != "landing" {
+		t.Errorf("expected no key prefix, got %s", bucket.KeyPrefix("landing"))
+	}
+
+	frontendEnvironment.Spec.ObjectStore = &crd.ObjectStoreConfig{
+		Bucket:     "stage-assets",
+		Endpoint:   "s3.us-east-1.amazonaws.com",
+		Port:       utils.Int32Ptr(443),
+		PathPrefix: "stage/v2",
+	}
+	bucket, err = ExtractBucketConfig(frontendEnvironment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bucket.Location() != "s3.us-east-1.amazonaws.com:443/stage-assets/stage/v2" || !*bucket.TLS {
+		t.Errorf("expected the environment settings with TLS, got %s tls=%v", bucket.Location(), *bucket.TLS)
+	}
+	if *bucket.Region != "us-east-1" {
+		t.Errorf("expected the region to fall back to the operator setting, got %s", *bucket.Region)
+	}
+	if bucket.KeyPrefix("landing") != "stage/v2/landing" || bucket.BucketPath() != "stage-assets/stage/v2" {
+		t.Errorf("expected the path prefix, got %s and %s", bucket.KeyPrefix("landing"), bucket.BucketPath())
+	}
+
+	frontendEnvironment.Spec.ObjectStore.TLS = utils.FalsePtr()
+	if bucket, _ = ExtractBucketConfig(frontendEnvironment); *bucket.TLS {
+		t.Error("expected tls to override the port default")
+	}
+
+	t.Setenv("PUSHCACHE_AWS_REGION", "")
+	_, err = ExtractBucketConfig(frontendEnvironment)
+	if err == nil || !strings.Contains(err.Error(), "PUSHCACHE_AWS_REGION") {
+		t.Errorf("expected the missing region to be reported, got %v", err)
+	}
+}
+
+func TestEnvironmentObjectStore(t *testing.T) {
+	setPushCacheEnv(t)
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	frontendEnvironment.Spec.EnablePushCache = true
+	frontendEnvironment.Spec.ValpopImage = "quay.io/valpop:1"
+	frontendEnvironment.Spec.ObjectStore = &crd.ObjectStoreConfig{
+		Bucket:     "ephemeral-assets",
+		Endpoint:   "minio.ephemeral.svc",
+		PathPrefix: "env-boot",
+	}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend).WithStatusSubresource(&crd.Frontend{}).Build()
+	jobKey := types.NamespacedName{Name: "landing-frontend-pushcache", Namespace: "boot"}
+
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	job := &batchv1.Job{}
+	if err := pClient.Get(context.Background(), jobKey, job); err != nil {
+		t.Fatal(err)
+	}
+	command := job.Spec.Template.Spec.Containers[0].Command[2]
+	if !strings.Contains(command, "-r env-boot/landing ") || !strings.Contains(command, "--bucket ephemeral-assets --hostname minio.ephemeral.svc --port 9000 ") {
+		t.Errorf("expected the object store of the environment, got %q", command)
+	}
+
+	// Moving the environment to another prefix publishes the assets again
+	frontendEnvironment.Spec.ObjectStore.PathPrefix = "env-boot-2"
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	if err := pClient.Get(context.Background(), jobKey, job); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(job.Spec.Template.Spec.Containers[0].Command[2], "-r env-boot-2/landing ") {
+		t.Errorf("expected the job to be recreated for the new prefix, got %q", job.Spec.Template.Spec.Containers[0].Command[2])
+	}
+
+	reverseProxy := &ReverseProxyReconciliation{FrontendEnvironment: frontendEnvironment}
+	container, err := reverseProxy.createReverseProxyContainer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := map[string]string{}
+	for _, e := range container.Env {
+		env[e.Name] = e.Value
+	}
+	if env["MINIO_UPSTREAM_URL"] != "http://minio.ephemeral.svc:9000" || env["BUCKET_PATH_PREFIX"] != "ephemeral-assets/env-boot-2" {
+		t.Errorf("expected the reverse proxy to serve the object store of the environment, got %v", env)
+	}
+}
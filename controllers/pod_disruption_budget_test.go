@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+	policy "k8s.io/api/policy/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetPodDisruptionBudgetConfig(t *testing.T) {
+	frontend, frontendEnvironment := autoscalingTestObjects()
+
+	pdbConfig := getPodDisruptionBudgetConfig(frontend, frontendEnvironment)
+	if pdbConfig == nil || pdbConfig.MinAvailable != nil || pdbConfig.MaxUnavailable.IntValue() != 1 {
+		t.Fatalf("expected one unavailable pod by default, got %v", pdbConfig)
+	}
+
+	minAvailable := intstr.FromString("50%")
+	frontendEnvironment.Spec.DefaultPodDisruptionBudget = &crd.PodDisruptionBudgetConfig{MinAvailable: &minAvailable}
+	if pdbConfig = getPodDisruptionBudgetConfig(frontend, frontendEnvironment); pdbConfig.MinAvailable.String() != "50%" {
+		t.Errorf("expected the environment default, got %v", pdbConfig)
+	}
+
+	frontend.Spec.PodDisruptionBudget = &crd.PodDisruptionBudgetConfig{Disabled: true}
+	if pdbConfig = getPodDisruptionBudgetConfig(frontend, frontendEnvironment); pdbConfig != nil {
+		t.Errorf("expected a disabled budget to opt out of the default, got %v", pdbConfig)
+	}
+}
+
+func TestFrontendPodDisruptionBudget(t *testing.T) {
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	frontendEnvironment.Spec.DefaultAutoscaling = nil
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend, frontendEnvironment).Build()
+	nn := types.NamespacedName{Name: "landing-frontend", Namespace: "boot"}
+
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	pdb := &policy.PodDisruptionBudget{}
+	if err := pClient.Get(context.Background(), nn, pdb); err != nil {
+		t.Fatalf("expected the pod disruption budget to be created: %v", err)
+	}
+	if pdb.Spec.MaxUnavailable == nil || pdb.Spec.MaxUnavailable.IntValue() != 1 || pdb.Spec.MinAvailable != nil {
+		t.Errorf("expected maxUnavailable 1, got %v", pdb.Spec)
+	}
+	if pdb.Spec.Selector.MatchLabels["frontend"] != "landing" {
+		t.Errorf("expected the budget to select the frontend pods, got %v", pdb.Spec.Selector)
+	}
+	if len(pdb.OwnerReferences) != 1 || pdb.OwnerReferences[0].Name != "landing" {
+		t.Errorf("expected the budget to be owned by the frontend, got %v", pdb.OwnerReferences)
+	}
+
+	minAvailable := intstr.FromInt32(1)
+	frontend.Spec.PodDisruptionBudget = &crd.PodDisruptionBudgetConfig{MinAvailable: &minAvailable}
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	if err := pClient.Get(context.Background(), nn, pdb); err != nil {
+		t.Fatal(err)
+	}
+	if pdb.Spec.MinAvailable == nil || pdb.Spec.MinAvailable.IntValue() != 1 || pdb.Spec.MaxUnavailable != nil {
+		t.Errorf("expected minAvailable 1 to replace maxUnavailable, got %v", pdb.Spec)
+	}
+
+	// A single replica would block node drains
+	frontend.Spec.Replicas = utils.Int32Ptr(1)
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	if err := pClient.Get(context.Background(), nn, &policy.PodDisruptionBudget{}); !k8serr.IsNotFound(err) {
+		t.Errorf("expected the budget to be removed for a single replica, got %v", err)
+	}
+
+	// Autoscaled Deployments are judged by their minimum replicas
+	frontend.Spec.Autoscaling = &crd.AutoscalingConfig{MinReplicas: utils.Int32Ptr(3), MaxReplicas: 6}
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	if err := pClient.Get(context.Background(), nn, &policy.PodDisruptionBudget{}); err != nil {
+		t.Errorf("expected the budget for an autoscaled Deployment, got %v", err)
+	}
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2025 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+)
+
+const (
+	pushCacheJobSuffix = "-frontend-pushcache"
+
+	// pushCacheHistoryLimit is the number of previous runs kept in the Frontend status
+	pushCacheHistoryLimit = 10
+
+	defaultPushCacheMessageLimit = 1024
+)
+
+// pushCacheAsset is a directory of the Frontend image and the key prefix valpop publishes it
+// under
+type pushCacheAsset struct {
+	Path        string
+	Destination string
+}
+
+// getPushCacheStableDestination returns the key prefix the stable assets of the Frontend are
+// published under, with and without assetSources. It is the frontend name the reverse proxy
+// has always served the /srv/dist assets from.
+func getPushCacheStableDestination(frontend *crd.Frontend) string {
+	return frontend.Name
+}
+
+// getPushCacheAssets resolves the assetSources of the Frontend, the channels default to their
+// asset roots and to the stable destination, the preview one under preview/
+func getPushCacheAssets(frontend *crd.Frontend) ([]pushCacheAsset, error) {
+	stableDestination := getPushCacheStableDestination(frontend)
+	assets := []pushCacheAsset{}
+	destinations := map[string]bool{}
+	for _, source := range frontend.Spec.AssetSources {
+		asset := pushCacheAsset{Path: source.Path, Destination: source.Destination}
+		root, destination := stableAssetRoot, stableDestination
+		if source.Channel == crd.AssetChannelPreview {
+			root, destination = previewAssetRoot, "preview/"+stableDestination
+		}
+		if asset.Path == "" {
+			asset.Path = root
+		}
+		if asset.Destination == "" {
+			asset.Destination = destination
+		}
+		if destinations[asset.Destination] {
+			return nil, fmt.Errorf("assetSources of %s publish to %s more than once", frontend.Name, asset.Destination)
+		}
+		destinations[asset.Destination] = true
+		assets = append(assets, asset)
+	}
+	return assets, nil
+}
+
+// getPushCacheAssetsAnnotation identifies the asset sources a push cache Job publishes, it is
+// empty for the Jobs publishing /srv/dist
+func getPushCacheAssetsAnnotation(assets []pushCacheAsset) string {
+	sources := []string{}
+	for _, asset := range assets {
+		sources = append(sources, asset.Path+"="+asset.Destination)
+	}
+	return strings.Join(sources, ",")
+}
+
+// pushCacheJobPredicate passes the push cache Jobs, their status changes are reported on the
+// Frontend
+func pushCacheJobPredicate() predicate.Funcs {
+	return predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return strings.HasSuffix(o.GetName(), pushCacheJobSuffix)
+	})
+}
+
+// getPushCacheRun reads the run of the push cache Job from its conditions
+func getPushCacheRun(j *batchv1.Job) *crd.PushCacheRun {
+	run := &crd.PushCacheRun{
+		JobName:     j.Name,
+		JobUID:      string(j.UID),
+		Image:       j.Spec.Template.Annotations["frontend-image"],
+		ValpopImage: j.Spec.Template.Annotations["valpop-image"],
+		RerunToken:  j.Spec.Template.Annotations["rerun-token"],
+		Phase:       crd.PushCacheRunning,
+		StartTime:   j.Status.StartTime,
+	}
+
+	for _, condition := range j.Status.Conditions {
+		if condition.Status != v1.ConditionTrue {
+			continue
+		}
+		finishTime := condition.LastTransitionTime
+		switch condition.Type {
+		case batchv1.JobComplete:
+			run.Phase = crd.PushCacheSucceeded
+			if j.Status.CompletionTime != nil {
+				finishTime = *j.Status.CompletionTime
+			}
+			run.CompletionTime = &finishTime
+		case batchv1.JobFailed:
+			run.Phase = crd.PushCacheFailed
+			run.CompletionTime = &finishTime
+			run.Message = fmt.Sprintf("%s: %s", condition.Reason, condition.Message)
+		}
+	}
+	return run
+}
+
+// getPushCachePodFailure returns why the containers of the latest pod of the Job failed, or
+// why they cannot start, e.g. an image that cannot be pulled
+func getPushCachePodFailure(ctx context.Context, pClient client.Client, j *batchv1.Job) (string, error) {
+	pods := &v1.PodList{}
+	if err := pClient.List(ctx, pods, client.InNamespace(j.Namespace), client.MatchingLabels{"job-name": j.Name}); err != nil {
+		return "", err
+	}
+	if len(pods.Items) == 0 {
+		return "", nil
+	}
+	sort.Slice(pods.Items, func(i, k int) bool {
+		return pods.Items[i].CreationTimestamp.Before(&pods.Items[k].CreationTimestamp)
+	})
+	pod := pods.Items[len(pods.Items)-1]
+
+	failures := []string{}
+	statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, containerStatus := range statuses {
+		if terminated := containerStatus.State.Terminated; terminated != nil && terminated.ExitCode != 0 {
+			failure := fmt.Sprintf("container %s terminated with %s (exit code %d)", containerStatus.Name, terminated.Reason, terminated.ExitCode)
+			if message := strings.TrimSpace(terminated.Message); message != "" {
+				failure += ": " + message
+			}
+			failures = append(failures, failure)
+		}
+		if waiting := containerStatus.State.Waiting; waiting != nil && waiting.Reason != "" && waiting.Reason != "ContainerCreating" && waiting.Reason != "PodInitializing" {
+			failures = append(failures, fmt.Sprintf("container %s is waiting with %s: %s", containerStatus.Name, waiting.Reason, waiting.Message))
+		}
+	}
+	return strings.Join(failures, "; "), nil
+}
+
+// AssetsPublishedCondition builds the AssetsPublished condition from the last push cache run
+// of a Frontend, the assets are published once a run of the current image succeeded
+func AssetsPublishedCondition(image string, run *crd.PushCacheRun) metav1.Condition {
+	condition := metav1.Condition{
+		Type:    crd.AssetsPublished,
+		Status:  metav1.ConditionFalse,
+		Reason:  "PushCacheRunning",
+		Message: fmt.Sprintf("Publishing the assets of %s", run.Image),
+	}
+	switch {
+	case run.Phase == crd.PushCacheFailed:
+		condition.Reason = "PushCacheFailed"
+		condition.Message = fmt.Sprintf("Publishing the assets of %s failed: %s", run.Image, run.Message)
+	case run.Phase == crd.PushCacheSucceeded && run.Image == image:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "PushCacheSucceeded"
+		condition.Message = fmt.Sprintf("Published the assets of %s", run.Image)
+	case run.Phase == crd.PushCacheSucceeded:
+		condition.Reason = "ImageChanged"
+		condition.Message = fmt.Sprintf("Published the assets of %s, %s is not published yet", run.Image, image)
+	}
+	return condition
+}
+
+// reconcilePushCacheStatus reports the push cache Job of the Frontend in its status. A Job
+// recreated for a new image moves the previous run to the history. Events are recorded and
+// the metrics observed when a run finishes.
+func (r *FrontendReconciliation) reconcilePushCacheStatus() error {
+	j := &batchv1.Job{}
+	nn := types.NamespacedName{Name: r.generatePushCacheJobName(), Namespace: r.Frontend.Namespace}
+	if err := r.Client.Get(r.Ctx, nn, j); err != nil {
+		if k8serr.IsNotFound(err) {
+			// The stale Job was deleted, it is recreated by the next reconciliation
+			return nil
+		}
+		return err
+	}
+
+	run := getPushCacheRun(j)
+	if run.Phase != crd.PushCacheSucceeded {
+		failure, err := getPushCachePodFailure(r.Ctx, r.Client, j)
+		if err != nil {
+			return err
+		}
+		if failure != "" {
+			run.Message = strings.TrimPrefix(fmt.Sprintf("%s %s", run.Message, failure), " ")
+		}
+	}
+	run.Message = truncateMessage(run.Message, defaultPushCacheMessageLimit)
+
+	status := r.Frontend.Status.PushCache.DeepCopy()
+	if status == nil {
+		status = &crd.PushCacheStatus{}
+	}
+	previous := status.LastRun
+	if previous != nil && previous.JobUID != run.JobUID {
+		if previous.Phase == crd.PushCacheRunning {
+			previous.Message = "The Job was replaced before it finished"
+		}
+		status.History = append([]crd.PushCacheRun{*previous}, status.History...)
+		if len(status.History) > pushCacheHistoryLimit {
+			status.History = status.History[:pushCacheHistoryLimit]
+		}
+		previous = nil
+	}
+	if run.Phase != crd.PushCacheRunning && (previous == nil || previous.Phase == crd.PushCacheRunning) {
+		r.recordPushCacheResult(run)
+	}
+	status.LastRun = run
+	return r.setPushCacheStatus(status)
+}
+
+// recordPushCacheResult records the Event and the metrics of a finished push cache run
+func (r *FrontendReconciliation) recordPushCacheResult(run *crd.PushCacheRun) {
+	outcome := "succeeded"
+	if run.Phase == crd.PushCacheFailed {
+		outcome = "failed"
+		pushCacheFailuresMetric.WithLabelValues(r.Frontend.Name).Inc()
+		r.recordEvent(v1.EventTypeWarning, "PushCacheFailed", "Publishing the assets of %s failed: %s", run.Image, run.Message)
+	} else {
+		r.recordEvent(v1.EventTypeNormal, "PushCacheSucceeded", "Published the assets of %s", run.Image)
+	}
+	if run.StartTime != nil && run.CompletionTime != nil {
+		pushCacheDurationMetric.WithLabelValues(r.Frontend.Name, outcome).Observe(run.CompletionTime.Sub(run.StartTime.Time).Seconds())
+	}
+}
+
+// setPushCacheStatus persists the status right away, the Events of a finished run must not be
+// recorded twice because a later step of the reconciliation failed
+func (r *FrontendReconciliation) setPushCacheStatus(status *crd.PushCacheStatus) error {
+	nn := types.NamespacedName{Name: r.Frontend.Name, Namespace: r.Frontend.Namespace}
+	if err := SetFrontendPushCacheStatus(r.Ctx, r.Client, nn, status, r.getPushCacheImage()); err != nil {
+		return err
+	}
+	r.Frontend.Status.PushCache = status
+	return nil
+}
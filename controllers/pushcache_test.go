@@ -0,0 +1,306 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func pushCacheTestJob(uid types.UID, image string, start time.Time) *batchv1.Job {
+	j := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "landing-frontend-pushcache", Namespace: "boot", UID: uid},
+		Status:     batchv1.JobStatus{StartTime: &metav1.Time{Time: start}},
+	}
+	j.Spec.Template.Annotations = map[string]string{
+		"frontend-image": image,
+		"valpop-image":   "quay.io/valpop:1",
+	}
+	return j
+}
+
+func TestReconcilePushCacheStatus(t *testing.T) {
+	ctx := context.Background()
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	job := pushCacheTestJob("job-1", "quay.io/landing:abc", start)
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "landing-frontend-pushcache-x1", Namespace: "boot", Labels: map[string]string{"job-name": "landing-frontend-pushcache"}},
+	}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend, job, pod).WithStatusSubresource(&crd.Frontend{}, &batchv1.Job{}, &v1.Pod{}).Build()
+	recorder := record.NewFakeRecorder(10)
+	reconcile := func() *crd.Frontend {
+		t.Helper()
+		r := &FrontendReconciliation{
+			Ctx:                 ctx,
+			Client:              pClient,
+			Recorder:            recorder,
+			Frontend:            frontend,
+			FrontendEnvironment: frontendEnvironment,
+		}
+		if err := r.reconcilePushCacheStatus(); err != nil {
+			t.Fatal(err)
+		}
+		stored := &crd.Frontend{}
+		if err := pClient.Get(ctx, types.NamespacedName{Name: "landing", Namespace: "boot"}, stored); err != nil {
+			t.Fatal(err)
+		}
+		return stored
+	}
+
+	stored := reconcile()
+	condition := meta.FindStatusCondition(stored.Status.Conditions, crd.AssetsPublished)
+	if stored.Status.PushCache == nil || stored.Status.PushCache.LastRun.Phase != crd.PushCacheRunning {
+		t.Fatalf("expected a running push cache, got %+v", stored.Status.PushCache)
+	}
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != "PushCacheRunning" {
+		t.Errorf("expected the assets to be publishing, got %+v", condition)
+	}
+
+	// The pod failure is surfaced along with the Job condition
+	pod.Status.ContainerStatuses = []v1.ContainerStatus{{
+		Name:  "valpop-pushcache",
+		State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 1, Reason: "Error", Message: "bucket frontend does not exist"}},
+	}}
+	if err := pClient.Status().Update(ctx, pod); err != nil {
+		t.Fatal(err)
+	}
+	job.Status.Conditions = []batchv1.JobCondition{{
+		Type:               batchv1.JobFailed,
+		Status:             v1.ConditionTrue,
+		Reason:             "BackoffLimitExceeded",
+		Message:            "Job has reached the specified backoff limit",
+		LastTransitionTime: metav1.Time{Time: start.Add(time.Minute)},
+	}}
+	if err := pClient.Status().Update(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	stored = reconcile()
+	lastRun := stored.Status.PushCache.LastRun
+	if lastRun.Phase != crd.PushCacheFailed || lastRun.CompletionTime == nil || lastRun.Image != "quay.io/landing:abc" {
+		t.Fatalf("expected a failed push cache, got %+v", lastRun)
+	}
+	if !strings.Contains(lastRun.Message, "BackoffLimitExceeded") || !strings.Contains(lastRun.Message, "terminated with Error (exit code 1): bucket frontend does not exist") {
+		t.Errorf("expected the job and pod failure, got %q", lastRun.Message)
+	}
+	condition = meta.FindStatusCondition(stored.Status.Conditions, crd.AssetsPublished)
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != "PushCacheFailed" {
+		t.Errorf("expected the assets not to be published, got %+v", condition)
+	}
+	if event := <-recorder.Events; !strings.HasPrefix(event, "Warning PushCacheFailed") {
+		t.Errorf("expected a warning event, got %q", event)
+	}
+
+	// A finished run is reported once
+	reconcile()
+	if len(recorder.Events) != 0 {
+		t.Errorf("expected no new event, got %q", <-recorder.Events)
+	}
+
+	// The Job recreated for a new image moves the failed run to the history
+	if err := pClient.Delete(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	frontend = stored
+	frontend.Spec.Image = "quay.io/landing:def"
+	if err := pClient.Update(ctx, frontend); err != nil {
+		t.Fatal(err)
+	}
+	job = pushCacheTestJob("job-2", "quay.io/landing:def", start.Add(time.Hour))
+	job.Status.CompletionTime = &metav1.Time{Time: start.Add(time.Hour + 5*time.Minute)}
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}}
+	if err := pClient.Create(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	if err := pClient.Delete(ctx, pod); err != nil {
+		t.Fatal(err)
+	}
+	stored = reconcile()
+	pushCache := stored.Status.PushCache
+	if pushCache.LastRun.Phase != crd.PushCacheSucceeded || pushCache.LastRun.JobUID != "job-2" || !pushCache.LastRun.CompletionTime.Equal(job.Status.CompletionTime) {
+		t.Errorf("expected the new run to succeed, got %+v", pushCache.LastRun)
+	}
+	if len(pushCache.History) != 1 || pushCache.History[0].JobUID != "job-1" || pushCache.History[0].Phase != crd.PushCacheFailed {
+		t.Errorf("expected the failed run in the history, got %+v", pushCache.History)
+	}
+	condition = meta.FindStatusCondition(stored.Status.Conditions, crd.AssetsPublished)
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		t.Errorf("expected the assets to be published, got %+v", condition)
+	}
+	if event := <-recorder.Events; !strings.HasPrefix(event, "Normal PushCacheSucceeded") {
+		t.Errorf("expected a normal event, got %q", event)
+	}
+}
+
+func TestPushCacheHistoryLimit(t *testing.T) {
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	history := []crd.PushCacheRun{}
+	for i := 0; i < pushCacheHistoryLimit; i++ {
+		history = append(history, crd.PushCacheRun{JobName: "landing-frontend-pushcache", JobUID: "old", Phase: crd.PushCacheSucceeded})
+	}
+	frontend.Status.PushCache = &crd.PushCacheStatus{
+		LastRun: &crd.PushCacheRun{JobName: "landing-frontend-pushcache", JobUID: "job-1", Phase: crd.PushCacheRunning},
+		History: history,
+	}
+	job := pushCacheTestJob("job-2", "quay.io/landing:abc", time.Now())
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend, job).WithStatusSubresource(&crd.Frontend{}).Build()
+	r := &FrontendReconciliation{
+		Ctx:                 context.Background(),
+		Client:              pClient,
+		Frontend:            frontend,
+		FrontendEnvironment: frontendEnvironment,
+	}
+	if err := r.reconcilePushCacheStatus(); err != nil {
+		t.Fatal(err)
+	}
+	history = r.Frontend.Status.PushCache.History
+	if len(history) != pushCacheHistoryLimit || history[0].JobUID != "job-1" || history[0].Message == "" {
+		t.Errorf("expected the replaced run first in a bounded history, got %d runs starting with %+v", len(history), history[0])
+	}
+}
+
+func TestAssetsPublishedCondition(t *testing.T) {
+	run := &crd.PushCacheRun{Image: "quay.io/landing:abc", Phase: crd.PushCacheSucceeded}
+	if condition := AssetsPublishedCondition("quay.io/landing:abc", run); condition.Status != metav1.ConditionTrue {
+		t.Errorf("expected the current image to be published, got %+v", condition)
+	}
+	if condition := AssetsPublishedCondition("quay.io/landing:def", run); condition.Status != metav1.ConditionFalse || condition.Reason != "ImageChanged" {
+		t.Errorf("expected the new image not to be published yet, got %+v", condition)
+	}
+}
+
+func TestPushCacheRerunToken(t *testing.T) {
+	setPushCacheEnv(t)
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	frontendEnvironment.Spec.EnablePushCache = true
+	frontendEnvironment.Spec.ValpopImage = "quay.io/valpop:1"
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend).WithStatusSubresource(&crd.Frontend{}).Build()
+	jobKey := types.NamespacedName{Name: "landing-frontend-pushcache", Namespace: "boot"}
+	getJob := func() *batchv1.Job {
+		t.Helper()
+		job := &batchv1.Job{}
+		if err := pClient.Get(context.Background(), jobKey, job); err != nil {
+			t.Fatal(err)
+		}
+		return job
+	}
+
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	job := getJob()
+	if _, ok := job.Spec.Template.Annotations["rerun-token"]; ok {
+		t.Errorf("expected no rerun token without one on the Frontend, got %v", job.Spec.Template.Annotations)
+	}
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	if rerun := getJob(); rerun.ResourceVersion != job.ResourceVersion {
+		t.Error("expected the job to be kept without a new rerun token")
+	}
+
+	// A new token recreates the job of the Frontend
+	frontend.Spec.Jobs = &crd.FrontendJobsConfig{RerunToken: "2025-03-01T10:00:00Z"}
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	if annotations := getJob().Spec.Template.Annotations; annotations["rerun-token"] != "2025-03-01T10:00:00Z" {
+		t.Errorf("expected the job to be recreated for the rerun token, got %v", annotations)
+	}
+}
+
+func TestPushCacheAssetSources(t *testing.T) {
+	setPushCacheEnv(t)
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	frontendEnvironment.Spec.EnablePushCache = true
+	frontendEnvironment.Spec.ValpopImage = "quay.io/valpop:1"
+	frontendEnvironment.Spec.ObjectStore = &crd.ObjectStoreConfig{PathPrefix: "env-boot"}
+	frontend.Spec.AssetsPrefix = "settings"
+	frontend.Spec.AssetSources = []crd.AssetSource{
+		{},
+		{Channel: crd.AssetChannelPreview},
+		{Path: "/srv/docs", Destination: "docs/landing"},
+	}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend).WithStatusSubresource(&crd.Frontend{}).Build()
+
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	job := &batchv1.Job{}
+	if err := pClient.Get(context.Background(), types.NamespacedName{Name: "landing-frontend-pushcache", Namespace: "boot"}, job); err != nil {
+		t.Fatal(err)
+	}
+	copyCommand := job.Spec.Template.Spec.InitContainers[0].Command[2]
+	for _, expected := range []string{
+		"cp -r /opt/app-root/src/dist/stable/. /assets/0/",
+		"cp -r /opt/app-root/src/dist/preview/. /assets/1/",
+		"cp -r /srv/docs/. /assets/2/",
+	} {
+		if !strings.Contains(copyCommand, expected) {
+			t.Errorf("expected %q in the copy command, got %q", expected, copyCommand)
+		}
+	}
+	command := job.Spec.Template.Spec.Containers[0].Command[2]
+	for _, expected := range []string{
+		"-r env-boot/landing -s /assets/0 ",
+		"-r env-boot/preview/landing -s /assets/1 ",
+		"-r env-boot/docs/landing -s /assets/2 ",
+	} {
+		if !strings.Contains(command, expected) {
+			t.Errorf("expected %q in the valpop command, got %q", expected, command)
+		}
+	}
+	if strings.Count(command, "valpop populate") != 3 {
+		t.Errorf("expected a valpop run per source, got %q", command)
+	}
+
+	// Changing the sources publishes the assets again
+	frontend.Spec.AssetSources = frontend.Spec.AssetSources[:2]
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	if err := pClient.Get(context.Background(), types.NamespacedName{Name: "landing-frontend-pushcache", Namespace: "boot"}, job); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(job.Spec.Template.Spec.Containers[0].Command[2], "/assets/2") {
+		t.Errorf("expected the job to be recreated for the new sources, got %q", job.Spec.Template.Spec.Containers[0].Command[2])
+	}
+
+	frontend.Spec.AssetSources = append(frontend.Spec.AssetSources, crd.AssetSource{Path: "/srv/dist", Destination: "landing"})
+	if _, err := getPushCacheAssets(frontend); err == nil || !strings.Contains(err.Error(), "landing more than once") {
+		t.Errorf("expected the duplicate destination to be reported, got %v", err)
+	}
+}
+
+func TestPushCacheStableDestination(t *testing.T) {
+	setPushCacheEnv(t)
+	keyPrefix := func(assetSources []crd.AssetSource) string {
+		frontend, frontendEnvironment := autoscalingTestObjects()
+		frontendEnvironment.Spec.EnablePushCache = true
+		frontendEnvironment.Spec.ValpopImage = "quay.io/valpop:1"
+		frontendEnvironment.Spec.ObjectStore = &crd.ObjectStoreConfig{PathPrefix: "env-boot"}
+		frontend.Spec.AssetSources = assetSources
+		pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend).WithStatusSubresource(&crd.Frontend{}).Build()
+
+		reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+		job := &batchv1.Job{}
+		if err := pClient.Get(context.Background(), types.NamespacedName{Name: "landing-frontend-pushcache", Namespace: "boot"}, job); err != nil {
+			t.Fatal(err)
+		}
+		fields := strings.Fields(job.Spec.Template.Spec.Containers[0].Command[2])
+		for i, field := range fields {
+			if field == "-r" && i+1 < len(fields) {
+				return fields[i+1]
+			}
+		}
+		t.Fatalf("expected a key prefix in the valpop command, got %v", fields)
+		return ""
+	}
+
+	withoutSources := keyPrefix(nil)
+	withStableSource := keyPrefix([]crd.AssetSource{{Channel: crd.AssetChannelStable}})
+	if withoutSources != "env-boot/landing" || withStableSource != withoutSources {
+		t.Errorf("expected the stable assets under env-boot/landing with and without assetSources, got %s and %s", withoutSources, withStableSource)
+	}
+}
@@ -3,16 +3,18 @@ package controllers
 import (
 	"context"
 	"crypto/sha256"
-	_ "embed"
 	"encoding/json"
 	"fmt"
 	"maps"
-	"os"
+	"net/http"
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	"github.com/RedHatInsights/frontend-operator/controllers/cdn"
+	"github.com/RedHatInsights/frontend-operator/controllers/registry"
 	localUtil "github.com/RedHatInsights/frontend-operator/controllers/utils"
 	resCache "github.com/RedHatInsights/rhc-osdk-utils/resourceCache"
 	"github.com/RedHatInsights/rhc-osdk-utils/utils"
@@ -20,8 +22,10 @@ import (
 
 	prom "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	apps "k8s.io/api/apps/v1"
+	autoscaling "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
+	policy "k8s.io/api/policy/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -38,6 +42,19 @@ import (
 const (
 	RoutePrefixDefault      = "apps"
 	AkamaiSecretNameDefault = "akamai"
+	// defaultAutoscalingCPUUtilization is the CPU target of autoscalers without a configured target
+	defaultAutoscalingCPUUtilization int32 = 80
+)
+
+// Names of the generated config maps. The environment config maps are named after the
+// environment and live next to the frontends, the context config maps are the copies
+// propagated to the FrontendEnvironment TargetNamespaces.
+const (
+	WidgetRegistryConfigMapSuffix               = "-widget-registry"
+	BaseWidgetDashboardTemplatesConfigMapSuffix = "-base-widget-dashboard-templates"
+	FrontendContextConfigMapName                = "feo-context-cfg"
+	WidgetRegistryContextConfigMapName          = "widget-registry-cfg"
+	BaseWidgetDashboardTemplatesContextName     = "base-widget-dashboard-templates-cfg"
 )
 
 type FrontendReconciliation struct {
@@ -49,45 +66,38 @@ type FrontendReconciliation struct {
 	Frontend            *crd.Frontend
 	Ctx                 context.Context
 	Client              client.Client
+	// NewPurger returns the client purging the CDN cache, defaults to cdn.NewPurger
+	NewPurger func(cdn.Config) (cdn.Purger, error)
+	// NewResolver returns the client resolving the image digests, defaults to registry.NewResolver
+	NewResolver func(registry.Config) (registry.Resolver, error)
+	// RequeueAfter is set when a step of the reconciliation has to be checked again later
+	RequeueAfter time.Duration
 }
 
-// setupConfigMapWithLabels creates a ConfigMap with the specified name and namespace,
-// applies labels, and optionally sets the recycle annotation for pod restart
-func (r *FrontendReconciliation) setupConfigMapWithLabels(nn types.NamespacedName, markForRestart bool) *v1.ConfigMap {
-	cfgMap := &v1.ConfigMap{}
-	if markForRestart {
-		if cfgMap.Annotations == nil {
-			cfgMap.Annotations = map[string]string{}
-		}
-		// Flag to trigger pod restart if config map changes
-		cfgMap.Annotations["qontract.recycle"] = "true"
-	}
-
-	labels := r.FrontendEnvironment.GetLabels()
-	labler := utils.GetCustomLabeler(labels, nn, r.FrontendEnvironment)
-	labler(cfgMap)
-
-	return cfgMap
+// skippedConfigItem is an entry of a Frontend's config that could not be placed in the generated config
+type skippedConfigItem struct {
+	FrontendName      string
+	FrontendNamespace string
+	Kind              string
+	ID                string
+	Reason            string
 }
 
-//go:embed templates/Caddyfile
-var caddyFileTemplate string
-
-func (r *FrontendReconciliation) run() error {
+func (s skippedConfigItem) String() string {
+	return fmt.Sprintf("%s %s: %s", s.Kind, s.ID, s.Reason)
+}
 
-	configMaps, err := r.setupConfigMaps()
-	if err != nil {
-		return err
+func skippedConfigItemIDs(items []skippedConfigItem) string {
+	ids := []string{}
+	for _, item := range items {
+		ids = append(ids, item.ID)
 	}
+	return strings.Join(ids, ",")
+}
 
-	var configHashBase []map[string]string
-	var configHash string
-
-	for _, cm := range configMaps {
-		configHashBase = append(configHashBase, cm.Data)
-	}
+func (r *FrontendReconciliation) run() error {
 
-	configHash, err = createConfigmapHash(configHashBase)
+	configHash, err := r.getConfigHash()
 	if err != nil {
 		return err
 	}
@@ -96,28 +106,109 @@ func (r *FrontendReconciliation) run() error {
 	annotationHashes = append(annotationHashes, map[string]string{"configHash": configHash})
 
 	if r.Frontend.Spec.Image != "" {
+		// The digest is resolved first, the Deployment and the push cache Job run the pinned image
+		if imageResolutionEnabled(r.FrontendEnvironment) {
+			if err := r.reconcileImageDigest(); err != nil {
+				return err
+			}
+		} else if r.Frontend.Status.Image != nil {
+			if err := r.setImageStatus(nil); err != nil {
+				return err
+			}
+		}
+		// The canary rollout decides the image of the Deployment
+		if canaryRolloutEnabled(r.Frontend) {
+			if err := r.reconcileRollout(); err != nil {
+				return err
+			}
+		} else if r.Frontend.Status.Rollout != nil {
+			if err := r.setRolloutStatus(nil); err != nil {
+				return err
+			}
+		}
 		if err := r.createFrontendDeployment(annotationHashes); err != nil {
 			return err
 		}
 		if err := r.createFrontendService(); err != nil {
 			return err
 		}
-		// If cache busting is enabled for the environment, add the akamai cache bust container
-		if r.FrontendEnvironment.Spec.EnableAkamaiCacheBust && r.FrontendEnvironment.Spec.AkamaiCacheBustImage != "" && !r.Frontend.Spec.AkamaiCacheBustDisable {
-			if err := r.createOrUpdateJob(r.generateCacheBustJobName, r.populateCacheBustContainer); err != nil {
+		if r.canaryActive() {
+			if err := r.createCanaryDeployment(annotationHashes); err != nil {
+				return err
+			}
+			if err := r.createCanaryService(); err != nil {
+				return err
+			}
+		}
+		if autoscalingConfig := getAutoscalingConfig(r.Frontend, r.FrontendEnvironment); autoscalingConfig != nil {
+			if err := r.createFrontendHPA(autoscalingConfig); err != nil {
+				return err
+			}
+		}
+		if err := r.createFrontendPDB(); err != nil {
+			return err
+		}
+		if err := r.deleteLegacyCacheBustResources(); err != nil {
+			return err
+		}
+		// If cache busting is enabled for the environment, purge the cache once the new image is rolled out
+		if cacheBustEnabled(r.Frontend, r.FrontendEnvironment) {
+			if err := r.reconcileCacheBust(); err != nil {
+				return err
+			}
+		} else if r.Frontend.Status.CacheBust != nil {
+			if err := r.setCacheBustStatus(nil); err != nil {
 				return err
 			}
 		}
 		// If push cache is enabled for the environment, add the push cache container
 		if r.FrontendEnvironment.Spec.EnablePushCache && r.Frontend.Spec.Image != "" {
+			if _, err := reconcileObjectStoreCredentials(r.Ctx, r.Client, r.FrontendEnvironment, r.Frontend.Namespace); err != nil {
+				return err
+			}
 			if err := r.createOrUpdateJob(r.generatePushCacheJobName, r.populatePushCacheContainer); err != nil {
 				return err
 			}
+			if err := r.reconcilePushCacheStatus(); err != nil {
+				return err
+			}
+		} else if r.Frontend.Status.PushCache != nil {
+			if err := r.setPushCacheStatus(nil); err != nil {
+				return err
+			}
 		}
 	}
 
-	if err := r.createFrontendIngress(); err != nil {
-		return err
+	switch {
+	case r.FrontendEnvironment.UsesGatewayAPI():
+		if err := r.createFrontendHTTPRoute(); err != nil {
+			return err
+		}
+	case r.FrontendEnvironment.UsesRoutes():
+		if err := r.createFrontendRoutes(); err != nil {
+			return err
+		}
+	default:
+		if err := r.createFrontendIngress(); err != nil {
+			return err
+		}
+		if r.canaryActive() {
+			if err := r.createCanaryIngress(); err != nil {
+				return err
+			}
+		}
+	}
+	// The HTTPRoute and Route idents are only registered in their routing mode
+	if !r.FrontendEnvironment.UsesGatewayAPI() {
+		nn := types.NamespacedName{Name: r.Frontend.Name, Namespace: r.Frontend.Namespace}
+		if err := deleteOwnedHTTPRoute(r.Ctx, r.Client, nn, r.Frontend.UID); err != nil {
+			return err
+		}
+	}
+	if !r.FrontendEnvironment.UsesRoutes() {
+		if err := deleteOwnedRoutes(r.Ctx, r.Client, r.Frontend.Namespace, map[string]string{"frontend": r.Frontend.Name}, r.Frontend.UID); err != nil {
+			return err
+		}
 	}
 
 	if r.FrontendEnvironment.Spec.Monitoring != nil && !r.Frontend.Spec.ServiceMonitor.Disabled && !r.FrontendEnvironment.Spec.Monitoring.Disabled {
@@ -128,6 +219,28 @@ func (r *FrontendReconciliation) run() error {
 	return nil
 }
 
+// getConfigHash hashes the environment config maps in the Frontend's namespace. The config
+// maps are generated by the EnvironmentConfigReconciler, the hash rolls the Deployment when
+// they change. Config maps that are not generated yet hash as empty.
+func (r *FrontendReconciliation) getConfigHash() (string, error) {
+	var configHashBase []map[string]string
+
+	for _, name := range []string{
+		r.Frontend.Spec.EnvName,
+		r.Frontend.Spec.EnvName + WidgetRegistryConfigMapSuffix,
+		r.Frontend.Spec.EnvName + BaseWidgetDashboardTemplatesConfigMapSuffix,
+	} {
+		cfgMap := &v1.ConfigMap{}
+		err := r.Client.Get(r.Ctx, types.NamespacedName{Name: name, Namespace: r.Frontend.Namespace}, cfgMap)
+		if err != nil && !k8serr.IsNotFound(err) {
+			return "", err
+		}
+		configHashBase = append(configHashBase, cfgMap.Data)
+	}
+
+	return createConfigmapHash(configHashBase)
+}
+
 func populateContainerVolumeMounts(frontendEnvironment *crd.FrontendEnvironment, frontend *crd.Frontend) []v1.VolumeMount {
 
 	volumeMounts := []v1.VolumeMount{}
@@ -206,7 +319,7 @@ func GetResourceRequirements(frontendEnvironment *crd.FrontendEnvironment) (reso
 	return cpuRequests, memoryRequests, cpuLimit, memoryLimit
 }
 
-func populateContainer(d *apps.Deployment, frontend *crd.Frontend, frontendEnvironment *crd.FrontendEnvironment) {
+func populateContainer(d *apps.Deployment, frontend *crd.Frontend, frontendEnvironment *crd.FrontendEnvironment, image string) {
 	// Get the resource requirements
 	cpuRequests, memoryRequests, cpuLimit, memoryLimit := GetResourceRequirements(frontendEnvironment)
 
@@ -221,7 +334,7 @@ func populateContainer(d *apps.Deployment, frontend *crd.Frontend, frontendEnvir
 	// Modify the object to set the things we care about
 	d.Spec.Template.Spec.Containers = []v1.Container{{
 		Name:  "fe-image",
-		Image: frontend.Spec.Image,
+		Image: image,
 		Ports: []v1.ContainerPort{
 			{
 				Name:          "web",
@@ -286,21 +399,6 @@ func getAkamaiSecret(ctx context.Context, client client.Client, frontend *crd.Fr
 	return secret, nil
 }
 
-// constructAkamaiEdgercFileFromSecret constructs the akamai edgerc file from the secret
-func makeAkamaiEdgercFileFromSecret(secret *v1.Secret) string {
-	edgercFile := "[default]\n"
-	edgercFile += fmt.Sprintf("host = %s\n", secret.Data["host"])
-	edgercFile += fmt.Sprintf("access_token = %s\n", secret.Data["access_token"])
-	edgercFile += fmt.Sprintf("client_token = %s\n", secret.Data["client_token"])
-	edgercFile += fmt.Sprintf("client_secret = %s\n", secret.Data["client_secret"])
-	edgercFile += "[ccu]\n"
-	edgercFile += fmt.Sprintf("host = %s\n", secret.Data["host"])
-	edgercFile += fmt.Sprintf("access_token = %s\n", secret.Data["access_token"])
-	edgercFile += fmt.Sprintf("client_token = %s\n", secret.Data["client_token"])
-	edgercFile += fmt.Sprintf("client_secret = %s\n", secret.Data["client_secret"])
-	return edgercFile
-}
-
 func createCachePurgePathList(frontend *crd.Frontend, frontendEnvironment *crd.FrontendEnvironment) []string {
 	var purgePaths []string
 
@@ -363,97 +461,6 @@ func createCachePurgePathList(frontend *crd.Frontend, frontendEnvironment *crd.F
 	return purgePaths
 }
 
-// populateCacheBustContainer adds the akamai cache bust container to the deployment
-func (r *FrontendReconciliation) populateCacheBustContainer(j *batchv1.Job) error {
-
-	// Get the akamai secret
-	akamaiSecretName := getAkamaiSecretName(r.FrontendEnvironment)
-	secret, err := getAkamaiSecret(r.Ctx, r.Client, r.Frontend, akamaiSecretName)
-	if err != nil {
-		return err
-	}
-	// Make the akamai file from the secret
-	edgercFile := makeAkamaiEdgercFileFromSecret(secret)
-
-	configMap := &v1.ConfigMap{}
-	configMap.SetName("akamai-edgerc")
-	configMap.SetNamespace(r.Frontend.Namespace)
-
-	nn := types.NamespacedName{
-		Name:      "akamai-edgerc",
-		Namespace: r.Frontend.Namespace,
-	}
-	labels := r.FrontendEnvironment.GetLabels()
-	labler := utils.GetCustomLabeler(labels, nn, r.FrontendEnvironment)
-	labler(configMap)
-
-	configMap.SetOwnerReferences([]metav1.OwnerReference{r.Frontend.MakeOwnerReference()})
-
-	// Add the akamai edgerc file to the configmap
-	configMap.Data = map[string]string{
-		"edgerc": edgercFile,
-	}
-
-	// Create the configmap with the Client if it doesn't already exist
-	if err := r.Client.Create(r.Ctx, configMap); err != nil {
-		if !k8serr.IsAlreadyExists(err) {
-			return err
-		}
-	}
-
-	akamaiVolume := v1.Volume{
-		Name: "akamai-edgerc",
-		VolumeSource: v1.VolumeSource{
-			ConfigMap: &v1.ConfigMapVolumeSource{
-				LocalObjectReference: v1.LocalObjectReference{
-					Name: "akamai-edgerc",
-				},
-			},
-		},
-	}
-
-	j.Spec.Template.Spec.Volumes = []v1.Volume{akamaiVolume}
-
-	// Get the paths to cache bust
-	pathsToCacheBust := createCachePurgePathList(r.Frontend, r.FrontendEnvironment)
-
-	// Construct the akamai cache bust command
-	command := fmt.Sprintf("sleep 120; /cli/.akamai-cli/src/cli-purge/bin/akamai-purge --edgerc /opt/app-root/edgerc delete %s", strings.Join(pathsToCacheBust, " "))
-
-	// Modify the obejct to set the things we care about
-	cacheBustContainer := v1.Container{
-		Name:  "akamai-cache-bust",
-		Image: r.FrontendEnvironment.Spec.AkamaiCacheBustImage,
-		// Mount the akamai edgerc file from the configmap
-		VolumeMounts: []v1.VolumeMount{
-			{
-				Name:      "akamai-edgerc",
-				MountPath: "/opt/app-root/edgerc",
-				SubPath:   "edgerc",
-			},
-		},
-		// Run the akamai cache bust script
-		Command: []string{"/bin/bash", "-c", command},
-	}
-	// add the container to the spec containers
-	j.Spec.Template.Spec.Containers = []v1.Container{cacheBustContainer}
-
-	// Add the restart policy
-	j.Spec.Template.Spec.RestartPolicy = v1.RestartPolicyNever
-
-	annotations := j.Spec.Template.ObjectMeta.Annotations
-	if annotations == nil {
-		annotations = make(map[string]string)
-	}
-	annotations["kube-linter.io/ignore-all"] = "we don't need no any checking"
-
-	j.Spec.Template.ObjectMeta.SetAnnotations(annotations)
-
-	// Add the akamai edgerc configmap to the deployment
-
-	return nil
-}
-
 // populatePushCacheContainer adds the push cache container to the deployment
 func (r *FrontendReconciliation) populatePushCacheContainer(j *batchv1.Job) error {
 	configMap := &v1.ConfigMap{}
@@ -503,17 +510,11 @@ func (r *FrontendReconciliation) populatePushCacheContainer(j *batchv1.Job) erro
 		return err
 	}
 
-	objectStoreInfo, err := ExtractBucketConfigFromEnv()
+	objectStoreInfo, err := ExtractBucketConfig(r.FrontendEnvironment)
 	if err != nil {
 		return err
 	}
 
-	bucketName := objectStoreInfo.Name
-	awsUsername := objectStoreInfo.AccessKey
-	awsPassword := objectStoreInfo.SecretKey
-	hostname := objectStoreInfo.Endpoint
-	port := objectStoreInfo.Port
-
 	// Determine which image to use for the valpop container
 	// Use ValpopImage if specified, otherwise fall back to frontend image
 	assetsPath := "/assets"
@@ -535,11 +536,32 @@ func (r *FrontendReconciliation) populatePushCacheContainer(j *batchv1.Job) erro
 		},
 	}
 
+	assets, err := getPushCacheAssets(r.Frontend)
+	if err != nil {
+		return err
+	}
+
+	// Without assetSources /srv/dist is published under the stable destination, otherwise every
+	// source is copied to its own directory and published under its destination
+	copyCommand := "cp -r /srv/dist/* /assets/"
+	populateCommands := []string{}
+	if len(assets) == 0 {
+		populateCommands = append(populateCommands, r.getValpopCommand(objectStoreInfo, objectStoreInfo.KeyPrefix(getPushCacheStableDestination(r.Frontend)), assetsPath))
+	} else {
+		copyCommands := []string{}
+		for i, asset := range assets {
+			sourcePath := fmt.Sprintf("%s/%d", assetsPath, i)
+			copyCommands = append(copyCommands, fmt.Sprintf("mkdir -p %s && cp -r %s/. %s/", sourcePath, asset.Path, sourcePath))
+			populateCommands = append(populateCommands, r.getValpopCommand(objectStoreInfo, objectStoreInfo.KeyPrefix(asset.Destination), sourcePath))
+		}
+		copyCommand = strings.Join(copyCommands, " && ")
+	}
+
 	// Init container to copy assets from frontend image to shared volume
 	initContainer := v1.Container{
 		Name:    "copy-frontend-assets",
-		Image:   r.Frontend.Spec.Image,
-		Command: []string{"/bin/sh", "-c", "cp -r /srv/dist/* /assets/"},
+		Image:   r.getPushCacheImage(),
+		Command: []string{"/bin/sh", "-c", copyCommand},
 		VolumeMounts: []v1.VolumeMount{
 			{
 				Name:      "frontend-assets",
@@ -549,14 +571,15 @@ func (r *FrontendReconciliation) populatePushCacheContainer(j *batchv1.Job) erro
 	}
 	j.Spec.Template.Spec.InitContainers = []v1.Container{initContainer}
 
-	// Construct the pushcache startup command; removing the sleep command will result in the pushcache job being spin up continously, without delay, and uploading the assets to s3
-	command := fmt.Sprintf("valpop populate -r %s -s %s -i %s --valpop-image %s --timeout 172800 --bucket %s --hostname %s --port %s --username %s --password %s", r.Frontend.Name, assetsPath, r.Frontend.Spec.Image, valpopImage, *bucketName, *hostname, *port, *awsUsername, *awsPassword)
+	// The sources are published one after the other, the job fails with the first one failing
+	command := strings.Join(populateCommands, " && ")
 
 	// Modify the object to set the things we care about
 	pushCacheContainer := v1.Container{
 		Name:         "valpop-pushcache",
 		Image:        valpopImage,
 		VolumeMounts: volumeMounts,
+		Env:          getObjectStoreCredentialsEnv(r.FrontendEnvironment),
 		// Run the pushcache startup command
 		Command: []string{"/bin/bash", "-c", command},
 	}
@@ -571,6 +594,10 @@ func (r *FrontendReconciliation) populatePushCacheContainer(j *batchv1.Job) erro
 		annotations = make(map[string]string)
 	}
 	annotations["valpop-image"] = valpopImage
+	annotations["object-store"] = objectStoreInfo.Location()
+	if assetSources := getPushCacheAssetsAnnotation(assets); assetSources != "" {
+		annotations["asset-sources"] = assetSources
+	}
 	annotations["kube-linter.io/ignore-all"] = "we don't need no any checking"
 
 	j.Spec.Template.ObjectMeta.SetAnnotations(annotations)
@@ -578,7 +605,17 @@ func (r *FrontendReconciliation) populatePushCacheContainer(j *batchv1.Job) erro
 	return nil
 }
 
-func populateVolumes(d *apps.Deployment, frontend *crd.Frontend, frontendEnvironment *crd.FrontendEnvironment) {
+// getValpopCommand returns the valpop command publishing the assets in sourcePath under keyPrefix
+func (r *FrontendReconciliation) getValpopCommand(objectStoreInfo *ObjectStoreBucket, keyPrefix, sourcePath string) string {
+	// Construct the pushcache startup command; removing the sleep command will result in the pushcache job being spin up continously, without delay, and uploading the assets to s3
+	// The credentials are still part of the valpop command line: valpop only takes them as
+	// --username and --password flags, bash expands them from the Secret backed env vars. Moving
+	// them off the command line is blocked until valpop reads them from its environment or a
+	// file, the flags are dropped then.
+	return fmt.Sprintf(`valpop populate -r %s -s %s -i %s --valpop-image %s --timeout 172800 --bucket %s --hostname %s --port %s --username "$PUSHCACHE_AWS_ACCESS_KEY_ID" --password "$PUSHCACHE_AWS_SECRET_ACCESS_KEY"`, keyPrefix, sourcePath, r.getPushCacheImage(), r.FrontendEnvironment.Spec.ValpopImage, *objectStoreInfo.Name, *objectStoreInfo.Endpoint, *objectStoreInfo.Port)
+}
+
+func populateVolumes(d *apps.Deployment, frontend *crd.Frontend, frontendEnvironment *crd.FrontendEnvironment, caddyfileKey string) {
 	// By default we just want the config and caddy volume
 	volumes := []v1.Volume{}
 	volumes = append(volumes, v1.Volume{
@@ -598,7 +635,7 @@ func populateVolumes(d *apps.Deployment, frontend *crd.Frontend, frontendEnviron
 					Name: frontend.Spec.EnvName,
 				},
 				Items: []v1.KeyToPath{{
-					Key:  "Caddyfile",
+					Key:  caddyfileKey,
 					Path: "Caddyfile",
 				}},
 			},
@@ -633,65 +670,6 @@ func populateVolumes(d *apps.Deployment, frontend *crd.Frontend, frontendEnviron
 	d.Spec.Template.Spec.Volumes = volumes
 }
 
-// ObjectStoreBucket represents the configuration for an object storage bucket.
-type ObjectStoreBucket struct {
-	AccessKey *string
-	SecretKey *string
-	Name      *string
-	Region    *string
-	Endpoint  *string
-	Port      *string
-	TLS       *bool
-}
-
-// ExtractBucketConfigFromEnv extracts ObjectStoreBucket configuration from environment variables
-func ExtractBucketConfigFromEnv() (*ObjectStoreBucket, error) {
-	// Required environment variables
-	accessKeyID := os.Getenv("PUSHCACHE_AWS_ACCESS_KEY_ID")
-	if accessKeyID == "" {
-		return nil, fmt.Errorf("required environment variable PUSHCACHE_AWS_ACCESS_KEY_ID is not set")
-	}
-
-	secretAccessKey := os.Getenv("PUSHCACHE_AWS_SECRET_ACCESS_KEY")
-	if secretAccessKey == "" {
-		return nil, fmt.Errorf("required environment variable PUSHCACHE_AWS_SECRET_ACCESS_KEY is not set")
-	}
-
-	bucketName := os.Getenv("PUSHCACHE_AWS_BUCKET_NAME")
-	if bucketName == "" {
-		return nil, fmt.Errorf("required environment variable PUSHCACHE_AWS_BUCKET_NAME is not set")
-	}
-
-	region := os.Getenv("PUSHCACHE_AWS_REGION")
-	if region == "" {
-		return nil, fmt.Errorf("required environment variable PUSHCACHE_AWS_REGION is not set")
-	}
-
-	endpoint := os.Getenv("PUSHCACHE_AWS_ENDPOINT")
-	if endpoint == "" {
-		return nil, fmt.Errorf("required environment variable PUSHCACHE_AWS_ENDPOINT is not set")
-	}
-
-	port := os.Getenv("PUSHCACHE_AWS_PORT")
-	// Default Objectstore Port to "443" if not provided
-	if port == "" {
-		port = "443"
-	}
-
-	// Initialize the bucket configuration with required fields
-	bucketConfig := &ObjectStoreBucket{
-		Name:      &bucketName,
-		AccessKey: &accessKeyID,
-		SecretKey: &secretAccessKey,
-		Region:    &region,
-		Endpoint:  &endpoint,
-		Port:      utils.StringPtr(port),
-		TLS:       utils.TruePtr(), // TLS is assumed to be true by default
-	}
-
-	return bucketConfig, nil
-}
-
 // Add the env vars if eny are set
 func (r *FrontendReconciliation) populateEnvVars(d *apps.Deployment, frontendEnvironment *crd.FrontendEnvironment) {
 	envVars := []v1.EnvVar{}
@@ -708,6 +686,8 @@ func (r *FrontendReconciliation) populateEnvVars(d *apps.Deployment, frontendEnv
 	}
 
 	// Add the HTTP Headers to the env vars if the frontend environment has them
+	// Deprecated: CADDY_HEADER_POLICY carries the same headers, CADDY_HTTP_HEADERS is kept for the
+	// images that include it in their own Caddyfile
 	if frontendEnvironment.Spec.HTTPHeaders != nil {
 		envVars = append(envVars, v1.EnvVar{
 			Name:  "CADDY_HTTP_HEADERS",
@@ -715,6 +695,14 @@ func (r *FrontendReconciliation) populateEnvVars(d *apps.Deployment, frontendEnv
 		})
 	}
 
+	// The header policy is rendered into the Caddyfile of the environment
+	if headerPolicy := createCaddyfileHeaderPolicy(frontendEnvironment.Spec.HTTPHeaders, frontendEnvironment.Spec.HeaderPolicies, r.Frontend.Spec.HeaderPolicies); headerPolicy != "" {
+		envVars = append(envVars, v1.EnvVar{
+			Name:  "CADDY_HEADER_POLICY",
+			Value: headerPolicy,
+		})
+	}
+
 	envVars = append(envVars, v1.EnvVar{
 		Name:  "APP_NAME",
 		Value: r.Frontend.Name,
@@ -722,7 +710,7 @@ func (r *FrontendReconciliation) populateEnvVars(d *apps.Deployment, frontendEnv
 
 	envVars = append(envVars, v1.EnvVar{
 		Name:  "ROUTE_PATH",
-		Value: "/apps/$(APP_NAME)",
+		Value: fmt.Sprintf("/%s/$(APP_NAME)", getFrontendAssetsPrefix(r.Frontend)),
 	})
 
 	envVars = append(envVars, v1.EnvVar{
@@ -760,6 +748,81 @@ func createCaddyfileHeaderBlock(httpHeaders map[string]string) string {
 	return headerBlock
 }
 
+// caddyPathMatcher turns a header policy glob into a Caddy path matcher, a bare file name
+// matches in any directory
+func caddyPathMatcher(path string) string {
+	if strings.HasPrefix(path, "/") || strings.HasPrefix(path, "*") {
+		return path
+	}
+	return "*/" + path
+}
+
+// caddyQuote quotes a header value as a single Caddyfile token
+func caddyQuote(value string) string {
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
+
+// createCaddyfileHeaderFields renders the headers of a header block sorted by name, prefix is
+// prepended to every field that sets a value and an empty value removes the header unless it
+// is one of the protected headers
+func createCaddyfileHeaderFields(headers map[string]string, prefix string, protected map[string]bool) string {
+	names := []string{}
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := ""
+	for _, name := range names {
+		if headers[name] == "" {
+			if !protected[http.CanonicalHeaderKey(name)] {
+				fields += fmt.Sprintf("\t-%s\n", name)
+			}
+			continue
+		}
+		fields += fmt.Sprintf("\t%s%s %s\n", prefix, name, caddyQuote(headers[name]))
+	}
+	return fields
+}
+
+// createCaddyfileHeaderPolicy renders the httpHeaders and the header policies as Caddyfile
+// header directives. The httpHeaders and the environment policies are enforced on every
+// matching response. The Frontend policies are only defaults, Caddy applies them to the
+// headers neither the environment nor the application set, and they can not remove the
+// headers of the environment.
+func createCaddyfileHeaderPolicy(httpHeaders map[string]string, environmentPolicies, frontendPolicies []crd.HeaderPolicy) string {
+	environmentHeaders := map[string]bool{}
+	for name := range httpHeaders {
+		environmentHeaders[http.CanonicalHeaderKey(name)] = true
+	}
+	for _, policy := range environmentPolicies {
+		for name := range policy.Headers {
+			environmentHeaders[http.CanonicalHeaderKey(name)] = true
+		}
+	}
+
+	headerPolicy := ""
+	if len(httpHeaders) != 0 {
+		headerPolicy += "header {\n" + createCaddyfileHeaderFields(httpHeaders, "", nil) + "}\n"
+	}
+	// Directives with named matchers keep their order, so a later policy overwrites the headers
+	// of an earlier one
+	for i, policy := range append(append([]crd.HeaderPolicy{}, environmentPolicies...), frontendPolicies...) {
+		prefix, protected := "", map[string]bool(nil)
+		if i >= len(environmentPolicies) {
+			prefix, protected = "?", environmentHeaders
+		}
+		fields := createCaddyfileHeaderFields(policy.Headers, prefix, protected)
+		if fields == "" {
+			continue
+		}
+		matcher := fmt.Sprintf("@header_policy_%d", i)
+		headerPolicy += fmt.Sprintf("%s path %s\n", matcher, caddyPathMatcher(policy.Path))
+		headerPolicy += fmt.Sprintf("header %s {\n", matcher) + fields + "}\n"
+	}
+	return headerPolicy
+}
+
 func (r *FrontendReconciliation) createFrontendDeployment(annotationHashes []map[string]string) error {
 
 	// Create new empty struct
@@ -784,19 +847,33 @@ func (r *FrontendReconciliation) createFrontendDeployment(annotationHashes []map
 	labeler := utils.GetCustomLabeler(labels, nn, r.Frontend)
 	labeler(d)
 
-	// Set the replicas if specified, otherwise default to 1
-	if r.Frontend.Spec.Replicas != nil {
-		d.Spec.Replicas = r.Frontend.Spec.Replicas
-	} else {
-		if r.FrontendEnvironment.Spec.DefaultReplicas != nil {
-			d.Spec.Replicas = r.FrontendEnvironment.Spec.DefaultReplicas
-		} else {
-			d.Spec.Replicas = utils.Int32Ptr(1)
+	// The autoscaler owns the replicas when it is active, they are only seeded for new Deployments
+	if autoscaling := getAutoscalingConfig(r.Frontend, r.FrontendEnvironment); autoscaling != nil {
+		if d.Spec.Replicas == nil {
+			d.Spec.Replicas = getAutoscalingMinReplicas(autoscaling, r.Frontend, r.FrontendEnvironment)
 		}
+	} else {
+		d.Spec.Replicas = getReplicas(r.Frontend, r.FrontendEnvironment)
 	}
 
-	populateVolumes(d, r.Frontend, r.FrontendEnvironment)
-	populateContainer(d, r.Frontend, r.FrontendEnvironment)
+	if err := r.populateDeployment(d, r.getStableImage(), labels, annotationHashes); err != nil {
+		return err
+	}
+
+	// Inform the cache that our updates are complete
+	err := r.Cache.Update(CoreDeployment, d)
+	return err
+}
+
+// populateDeployment sets the pods of a Deployment serving the Frontend with the image, it is
+// shared by the Deployment of the Frontend and its canary
+func (r *FrontendReconciliation) populateDeployment(d *apps.Deployment, image string, labels map[string]string, annotationHashes []map[string]string) error {
+	caddyfileKey, err := r.getMountedCaddyfileKey()
+	if err != nil {
+		return err
+	}
+	populateVolumes(d, r.Frontend, r.FrontendEnvironment, caddyfileKey)
+	populateContainer(d, r.Frontend, r.FrontendEnvironment, image)
 	r.populateEnvVars(d, r.FrontendEnvironment)
 
 	d.Spec.Template.ObjectMeta.Labels = labels
@@ -814,10 +891,172 @@ func (r *FrontendReconciliation) createFrontendDeployment(annotationHashes []map
 	// Gabor wrote the string "we don't need no any checking" and we will never change it
 	deploymentAnnotation["kube-linter.io/ignore-all"] = "we don't need no any checking"
 	d.ObjectMeta.SetAnnotations(deploymentAnnotation)
+	return nil
+}
 
-	// Inform the cache that our updates are complete
-	err := r.Cache.Update(CoreDeployment, d)
-	return err
+// getReplicas returns the replicas of the Frontend if specified, otherwise the environment default or 1
+func getReplicas(frontend *crd.Frontend, frontendEnvironment *crd.FrontendEnvironment) *int32 {
+	if frontend.Spec.Replicas != nil {
+		return frontend.Spec.Replicas
+	}
+	if frontendEnvironment.Spec.DefaultReplicas != nil {
+		return frontendEnvironment.Spec.DefaultReplicas
+	}
+	return utils.Int32Ptr(1)
+}
+
+// getAutoscalingConfig returns the autoscaling of the Frontend, falling back to the environment
+// default. It returns nil when the Deployment replicas are not autoscaled.
+func getAutoscalingConfig(frontend *crd.Frontend, frontendEnvironment *crd.FrontendEnvironment) *crd.AutoscalingConfig {
+	autoscaling := frontend.Spec.Autoscaling
+	if autoscaling == nil {
+		autoscaling = frontendEnvironment.Spec.DefaultAutoscaling
+	}
+	if autoscaling == nil || autoscaling.Disabled || autoscaling.MaxReplicas == 0 {
+		return nil
+	}
+	return autoscaling
+}
+
+func getAutoscalingMinReplicas(autoscaling *crd.AutoscalingConfig, frontend *crd.Frontend, frontendEnvironment *crd.FrontendEnvironment) *int32 {
+	minReplicas := getReplicas(frontend, frontendEnvironment)
+	if autoscaling.MinReplicas != nil {
+		minReplicas = autoscaling.MinReplicas
+	}
+	if *minReplicas > autoscaling.MaxReplicas {
+		return utils.Int32Ptr(int(autoscaling.MaxReplicas))
+	}
+	return minReplicas
+}
+
+func utilizationMetric(resourceName v1.ResourceName, utilization int32) autoscaling.MetricSpec {
+	return autoscaling.MetricSpec{
+		Type: autoscaling.ResourceMetricSourceType,
+		Resource: &autoscaling.ResourceMetricSource{
+			Name: resourceName,
+			Target: autoscaling.MetricTarget{
+				Type:               autoscaling.UtilizationMetricType,
+				AverageUtilization: &utilization,
+			},
+		},
+	}
+}
+
+func (r *FrontendReconciliation) createFrontendHPA(autoscalingConfig *crd.AutoscalingConfig) error {
+	hpa := &autoscaling.HorizontalPodAutoscaler{}
+
+	// The autoscaler is named after the Deployment it scales
+	nn := types.NamespacedName{
+		Name:      r.Frontend.Name + "-frontend",
+		Namespace: r.Frontend.Namespace,
+	}
+
+	if err := r.Cache.Create(FrontendHPA, nn, hpa); err != nil {
+		return err
+	}
+
+	labels := r.Frontend.GetLabels()
+	labeler := utils.GetCustomLabeler(labels, nn, r.Frontend)
+	labeler(hpa)
+
+	hpa.Spec.ScaleTargetRef = autoscaling.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       nn.Name,
+	}
+	hpa.Spec.MinReplicas = getAutoscalingMinReplicas(autoscalingConfig, r.Frontend, r.FrontendEnvironment)
+	hpa.Spec.MaxReplicas = autoscalingConfig.MaxReplicas
+
+	hpa.Spec.Metrics = []autoscaling.MetricSpec{}
+	if autoscalingConfig.TargetCPUUtilizationPercentage != nil {
+		hpa.Spec.Metrics = append(hpa.Spec.Metrics, utilizationMetric(v1.ResourceCPU, *autoscalingConfig.TargetCPUUtilizationPercentage))
+	}
+	if autoscalingConfig.TargetMemoryUtilizationPercentage != nil {
+		hpa.Spec.Metrics = append(hpa.Spec.Metrics, utilizationMetric(v1.ResourceMemory, *autoscalingConfig.TargetMemoryUtilizationPercentage))
+	}
+	if len(hpa.Spec.Metrics) == 0 {
+		hpa.Spec.Metrics = append(hpa.Spec.Metrics, utilizationMetric(v1.ResourceCPU, defaultAutoscalingCPUUtilization))
+	}
+
+	return r.Cache.Update(FrontendHPA, hpa)
+}
+
+// getPodDisruptionBudgetConfig returns the budget of the Frontend, falling back to the
+// environment default. It returns nil when the budget is disabled.
+func getPodDisruptionBudgetConfig(frontend *crd.Frontend, frontendEnvironment *crd.FrontendEnvironment) *crd.PodDisruptionBudgetConfig {
+	pdbConfig := frontend.Spec.PodDisruptionBudget
+	if pdbConfig == nil {
+		pdbConfig = frontendEnvironment.Spec.DefaultPodDisruptionBudget
+	}
+	return resolvePodDisruptionBudgetConfig(pdbConfig)
+}
+
+// resolvePodDisruptionBudgetConfig applies the default of one unavailable pod
+func resolvePodDisruptionBudgetConfig(pdbConfig *crd.PodDisruptionBudgetConfig) *crd.PodDisruptionBudgetConfig {
+	if pdbConfig == nil {
+		maxUnavailable := intstr.FromInt32(1)
+		return &crd.PodDisruptionBudgetConfig{MaxUnavailable: &maxUnavailable}
+	}
+	if pdbConfig.Disabled {
+		return nil
+	}
+	if pdbConfig.MinAvailable == nil && pdbConfig.MaxUnavailable == nil {
+		maxUnavailable := intstr.FromInt32(1)
+		return &crd.PodDisruptionBudgetConfig{MaxUnavailable: &maxUnavailable}
+	}
+	return pdbConfig
+}
+
+// setPodDisruptionBudgetSpec fills the budget of the pods matching the labels
+func setPodDisruptionBudgetSpec(pdb *policy.PodDisruptionBudget, pdbConfig *crd.PodDisruptionBudgetConfig, labels map[string]string) {
+	pdb.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+	pdb.Spec.MinAvailable = nil
+	pdb.Spec.MaxUnavailable = nil
+	if pdbConfig.MinAvailable != nil {
+		minAvailable := *pdbConfig.MinAvailable
+		pdb.Spec.MinAvailable = &minAvailable
+	} else {
+		maxUnavailable := *pdbConfig.MaxUnavailable
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+	}
+}
+
+// createFrontendPDB creates the PodDisruptionBudget of the frontend Deployment when it runs
+// more than one replica. Autoscaled Deployments are judged by their minimum replicas.
+func (r *FrontendReconciliation) createFrontendPDB() error {
+	pdbConfig := getPodDisruptionBudgetConfig(r.Frontend, r.FrontendEnvironment)
+	if pdbConfig == nil {
+		return nil
+	}
+
+	replicas := getReplicas(r.Frontend, r.FrontendEnvironment)
+	if autoscalingConfig := getAutoscalingConfig(r.Frontend, r.FrontendEnvironment); autoscalingConfig != nil {
+		replicas = getAutoscalingMinReplicas(autoscalingConfig, r.Frontend, r.FrontendEnvironment)
+	}
+	// A single replica can not be protected without blocking node drains
+	if *replicas <= 1 {
+		return nil
+	}
+
+	pdb := &policy.PodDisruptionBudget{}
+
+	// The budget is named after the Deployment it protects
+	nn := types.NamespacedName{
+		Name:      r.Frontend.Name + "-frontend",
+		Namespace: r.Frontend.Namespace,
+	}
+
+	if err := r.Cache.Create(FrontendPDB, nn, pdb); err != nil {
+		return err
+	}
+
+	labels := r.Frontend.GetLabels()
+	labeler := utils.GetCustomLabeler(labels, nn, r.Frontend)
+	labeler(pdb)
+
+	setPodDisruptionBudgetSpec(pdb, pdbConfig, labels)
+
+	return r.Cache.Update(FrontendPDB, pdb)
 }
 
 func createPorts() []v1.ServicePort {
@@ -840,12 +1079,8 @@ func createPorts() []v1.ServicePort {
 	}
 }
 
-func (r *FrontendReconciliation) generateCacheBustJobName() string {
-	return r.Frontend.Name + "-frontend-cachebust"
-}
-
 func (r *FrontendReconciliation) generatePushCacheJobName() string {
-	return r.Frontend.Name + "-frontend-pushcache"
+	return r.Frontend.Name + pushCacheJobSuffix
 }
 
 // getExistingJob returns the existing job if it exists
@@ -877,10 +1112,14 @@ func (r *FrontendReconciliation) getExistingJob(jobName string) (*batchv1.Job, b
 }
 
 func (r *FrontendReconciliation) isJobFromCurrentFrontendImage(j *batchv1.Job) bool {
-	return j.Spec.Template.ObjectMeta.Annotations["frontend-image"] == r.Frontend.Spec.Image
+	return j.Spec.Template.ObjectMeta.Annotations["frontend-image"] == r.getPushCacheImage()
 }
 
 func (r *FrontendReconciliation) isJobFromCurrentValpopImage(j *batchv1.Job) bool {
+	// Only the push cache job runs valpop
+	if !strings.Contains(j.Name, "pushcache") {
+		return true
+	}
 	valpopImage, exists := j.Spec.Template.ObjectMeta.Annotations["valpop-image"]
 	if !exists {
 		// Old jobs without the annotation were created before valpop image tracking;
@@ -890,6 +1129,50 @@ func (r *FrontendReconciliation) isJobFromCurrentValpopImage(j *batchv1.Job) boo
 	return valpopImage == r.FrontendEnvironment.Spec.ValpopImage
 }
 
+// isJobFromCurrentObjectStore returns false for push cache jobs that published to another bucket,
+// path prefix or object store than the environment is configured with
+func (r *FrontendReconciliation) isJobFromCurrentObjectStore(j *batchv1.Job) bool {
+	if !strings.Contains(j.Name, "pushcache") {
+		return true
+	}
+	objectStoreInfo, err := ExtractBucketConfig(r.FrontendEnvironment)
+	if err != nil {
+		// The configuration error is returned when the job is populated
+		return true
+	}
+	// Jobs without the annotation were created before per environment object stores and are
+	// recreated like jobs without the valpop image annotation
+	return j.Spec.Template.ObjectMeta.Annotations["object-store"] == objectStoreInfo.Location()
+}
+
+// getRerunToken returns the token the Frontend reruns its jobs with
+func getRerunToken(frontend *crd.Frontend) string {
+	if frontend.Spec.Jobs == nil {
+		return ""
+	}
+	return frontend.Spec.Jobs.RerunToken
+}
+
+// isJobFromCurrentRerunToken returns false for jobs created before the rerun token of the Frontend
+// changed. Jobs without the annotation were created without a token.
+func (r *FrontendReconciliation) isJobFromCurrentRerunToken(j *batchv1.Job) bool {
+	return j.Spec.Template.ObjectMeta.Annotations["rerun-token"] == getRerunToken(r.Frontend)
+}
+
+// isJobFromCurrentAssetSources returns false for push cache jobs publishing other directories
+// of the image than the assetSources of the Frontend
+func (r *FrontendReconciliation) isJobFromCurrentAssetSources(j *batchv1.Job) bool {
+	if !strings.Contains(j.Name, "pushcache") {
+		return true
+	}
+	assets, err := getPushCacheAssets(r.Frontend)
+	if err != nil {
+		// The configuration error is returned when the job is populated
+		return true
+	}
+	return j.Spec.Template.ObjectMeta.Annotations["asset-sources"] == getPushCacheAssetsAnnotation(assets)
+}
+
 func (r *FrontendReconciliation) isJobBehindCutoffTimestamp(j *batchv1.Job, jobName, deployCutoffTimestamp string) bool {
 	if deployCutoffTimestamp == "" || !strings.Contains(jobName, "pushcache") {
 		return false
@@ -913,8 +1196,11 @@ func (r *FrontendReconciliation) manageExistingJob(jobName string) (bool, error)
 		return false, nil
 	}
 
-	// If it exists but is not from the current frontend image, valpop image, or is behind the cutoff timestamp, we delete it
-	if !r.isJobFromCurrentFrontendImage(j) || !r.isJobFromCurrentValpopImage(j) || r.isJobBehindCutoffTimestamp(j, jobName, r.FrontendEnvironment.Spec.DeployCutoffTimestampPushCache) {
+	// If it exists but is not from the current frontend image, valpop image, object store, asset sources or
+	// rerun token, is behind the cutoff timestamp or still holds the object store credentials in its spec, we
+	// delete it
+	if !r.isJobFromCurrentFrontendImage(j) || !r.isJobFromCurrentValpopImage(j) || !r.isJobFromCurrentObjectStore(j) ||
+		!r.isJobFromCurrentAssetSources(j) || !r.isJobFromCurrentRerunToken(j) || r.isJobBehindCutoffTimestamp(j, jobName, r.FrontendEnvironment.Spec.DeployCutoffTimestampPushCache) || hasInlineObjectStoreCredentials(j) {
 		backgroundDeletion := metav1.DeletePropagationBackground
 		return false, r.Client.Delete(r.Ctx, j, &client.DeleteOptions{
 			PropagationPolicy: &backgroundDeletion,
@@ -973,7 +1259,10 @@ func (r *FrontendReconciliation) createOrUpdateJob(generateJobName func() string
 	if annotations == nil {
 		annotations = make(map[string]string)
 	}
-	annotations["frontend-image"] = r.Frontend.Spec.Image
+	annotations["frontend-image"] = r.getPushCacheImage()
+	if rerunToken := getRerunToken(r.Frontend); rerunToken != "" {
+		annotations["rerun-token"] = rerunToken
+	}
 	annotations["kube-linter.io/ignore-all"] = "we don't need no any checking"
 
 	j.Spec.Template.ObjectMeta.SetAnnotations(annotations)
@@ -1099,11 +1388,7 @@ func (r *FrontendReconciliation) createAnnotationsAndPopulate(nn types.Namespace
 
 func (r *FrontendReconciliation) getFrontendPaths() []string {
 	frontendPaths := r.Frontend.Spec.Frontend.Paths
-	defaultPath := fmt.Sprintf("/apps/%s", r.Frontend.Name)
-
-	if r.Frontend.Spec.AssetsPrefix != "" {
-		defaultPath = fmt.Sprintf("/%s/%s", r.Frontend.Spec.AssetsPrefix, r.Frontend.Name)
-	}
+	defaultPath := getFrontendRoutePath(r.Frontend)
 
 	if !r.Frontend.Spec.Frontend.HasPath(defaultPath) {
 		frontendPaths = append(frontendPaths, defaultPath)
@@ -1354,17 +1639,19 @@ func getServiceTilePath(section string, group string) string {
 	return fmt.Sprintf("%s-%s", section, group)
 }
 
-func setupServiceTilesData(feList *crd.FrontendList, feEnvironment crd.FrontendEnvironment) ([]crd.FrontendServiceCategoryGenerated, []string) {
+func setupServiceTilesData(feList *crd.FrontendList, feEnvironment crd.FrontendEnvironment) ([]crd.FrontendServiceCategoryGenerated, []skippedConfigItem) {
 	categories := []crd.FrontendServiceCategoryGenerated{}
 	if feEnvironment.Spec.ServiceCategories == nil {
 		// skip if we do not have service categories
-		return categories, []string{}
+		return categories, []skippedConfigItem{}
 	}
 
 	// just a quick cache to make it easier and faster to assign tiles to their destination
 	tileGroupAccessMap := make(map[string]*[]crd.ServiceTile)
+	knownSections := make(map[string]bool)
 
 	for _, category := range *feEnvironment.Spec.ServiceCategories {
+		knownSections[category.ID] = true
 		groups := []crd.FrontendServiceCategoryGroupGenerated{}
 		for _, gr := range category.Groups {
 			tiles := []crd.ServiceTile{}
@@ -1387,7 +1674,7 @@ func setupServiceTilesData(feList *crd.FrontendList, feEnvironment crd.FrontendE
 		categories = append(categories, newCategory)
 	}
 
-	skippedTiles := []string{}
+	skippedTiles := []skippedConfigItem{}
 	for _, frontend := range feList.Items {
 		if frontend.Spec.FeoConfigEnabled && frontend.Spec.ServiceTiles != nil {
 			for _, tile := range frontend.Spec.ServiceTiles {
@@ -1398,7 +1685,17 @@ func setupServiceTilesData(feList *crd.FrontendList, feEnvironment crd.FrontendE
 					*groupTiles = append(*groupTiles, *tile)
 				} else {
 					// ignore the tile if destination does not exist
-					skippedTiles = append(skippedTiles, tile.ID)
+					reason := fmt.Sprintf("unknown section %q", tile.Section)
+					if knownSections[tile.Section] {
+						reason = fmt.Sprintf("unknown group %q in section %q", tile.Group, tile.Section)
+					}
+					skippedTiles = append(skippedTiles, skippedConfigItem{
+						FrontendName:      frontend.Name,
+						FrontendNamespace: frontend.Namespace,
+						Kind:              "service tile",
+						ID:                tile.ID,
+						Reason:            reason,
+					})
 				}
 			}
 		}
@@ -1449,13 +1746,52 @@ type NavSegmentCacheEntry struct {
 	IsFilled bool
 }
 
-func fillNavRefsTree(navItems []crd.ChromeNavItem, navSegmentsCache map[string]map[string]*NavSegmentCacheEntry, depth uint) ([]crd.ChromeNavItem, error) {
+// navRefsContext tracks the segment references that are being resolved so that reference
+// cycles and dangling references can be reported against the frontend that made them.
+type navRefsContext struct {
+	// frontend owning the nav items that are being filled, empty for bundle segment items
+	// which carry their frontend in FrontendRef
+	frontendName string
+	// frontend/segment keys of the segments currently being expanded
+	chain  []string
+	issues *[]skippedConfigItem
+}
+
+func getSegmentRefKey(segmentRef *crd.SegmentRef) string {
+	return fmt.Sprintf("%s/%s", segmentRef.FrontendName, segmentRef.SegmentID)
+}
+
+func (c navRefsContext) enter(segmentRef *crd.SegmentRef) navRefsContext {
+	return navRefsContext{
+		frontendName: segmentRef.FrontendName,
+		chain:        append(slices.Clone(c.chain), getSegmentRefKey(segmentRef)),
+		issues:       c.issues,
+	}
+}
+
+func (c navRefsContext) report(navItem crd.ChromeNavItem, reason string) {
+	frontendName := c.frontendName
+	if frontendName == "" {
+		frontendName = navItem.FrontendRef
+	}
+	issue := skippedConfigItem{
+		FrontendName: frontendName,
+		Kind:         "segment reference",
+		ID:           getSegmentRefKey(navItem.SegmentRef),
+		Reason:       reason,
+	}
+	if !slices.Contains(*c.issues, issue) {
+		*c.issues = append(*c.issues, issue)
+	}
+}
+
+func fillNavRefsTree(navItems []crd.ChromeNavItem, navSegmentsCache map[string]map[string]*NavSegmentCacheEntry, depth uint, refs navRefsContext) ([]crd.ChromeNavItem, error) {
 	parsedNavItems := []crd.ChromeNavItem{}
-	// prevent infinite recursion and navigation nesting
+	// prevent runaway navigation nesting, reference cycles are detected separately
 	// currently max known depth is 2, so 10 should be more than enough for ever
-	// event the depth 2 is challenging when it comes to UX, this should prevent infinite reference loops
+	// event the depth 2 is challenging when it comes to UX
 	if depth > 10 {
-		return parsedNavItems, fmt.Errorf("maximum navigation depth reached")
+		return parsedNavItems, fmt.Errorf("maximum navigation depth reached: %s", strings.Join(refs.chain, " → "))
 	}
 	parsedNavItems = navItems
 	var err error
@@ -1464,18 +1800,32 @@ func fillNavRefsTree(navItems []crd.ChromeNavItem, navSegmentsCache map[string]m
 		// if navItem is a segment ref, replace it with the actual segment
 		if navItem.HasSegmentRef() && navItem.Href == "" && navItem.Title == "" {
 			segmentRef := navItem.SegmentRef
-			segmentRefCacheEntry, ok := navSegmentsCache[segmentRef.FrontendName][segmentRef.SegmentID]
+			frontendSegments, ok := navSegmentsCache[segmentRef.FrontendName]
 			if !ok {
 				// skip if segment ref does not exist
+				refs.report(navItem, fmt.Sprintf("frontend %q has no navigation segments", segmentRef.FrontendName))
+				continue
+			}
+			segmentRefCacheEntry, ok := frontendSegments[segmentRef.SegmentID]
+			if !ok {
+				refs.report(navItem, fmt.Sprintf("frontend %q has no navigation segment %q", segmentRef.FrontendName, segmentRef.SegmentID))
+				continue
+			}
+			if cycleStart := slices.Index(refs.chain, getSegmentRefKey(segmentRef)); cycleStart != -1 {
+				// leave the ref unresolved, it is removed from the output by filterUnknownNavRefs
+				cycle := append(slices.Clone(refs.chain[cycleStart:]), getSegmentRefKey(segmentRef))
+				refs.report(navItem, fmt.Sprintf("reference cycle %s", strings.Join(cycle, " → ")))
 				continue
 			}
 			segmentRefItems := segmentRefCacheEntry.NavItems
 			// pre-fill the cache for the segment to make the next pass quicker
 			if !segmentRefCacheEntry.IsFilled {
-				segmentRefItems, err = fillNavRefsTree(segmentRefItems, navSegmentsCache, depth+1)
+				segmentRefItems, err = fillNavRefsTree(segmentRefItems, navSegmentsCache, depth+1, refs.enter(segmentRef))
 				if err != nil {
 					return parsedNavItems, err
 				}
+				// unresolved refs are already reported, they must not be re-labeled as this segment below
+				segmentRefItems = filterUnknownNavRefs(segmentRefItems)
 				// add attributes required for the frontend local dev environment
 
 				// don't forget to mark the segment as filled and fill it
@@ -1500,7 +1850,7 @@ func fillNavRefsTree(navItems []crd.ChromeNavItem, navSegmentsCache map[string]m
 
 		// Make sure nested nav items have their refs filled as well
 		if navItem.IsExpandable() {
-			parsedRoutes, err := fillNavRefsTree(navItem.Routes, navSegmentsCache, depth+1)
+			parsedRoutes, err := fillNavRefsTree(navItem.Routes, navSegmentsCache, depth+1, refs)
 			if err != nil {
 				return parsedNavItems, err
 			}
@@ -1508,7 +1858,7 @@ func fillNavRefsTree(navItems []crd.ChromeNavItem, navSegmentsCache map[string]m
 		}
 
 		if navItem.IsGroup() {
-			parsedGroupItems, err := fillNavRefsTree(navItem.NavItems, navSegmentsCache, depth+1)
+			parsedGroupItems, err := fillNavRefsTree(navItem.NavItems, navSegmentsCache, depth+1, refs)
 			if err != nil {
 				return parsedNavItems, err
 			}
@@ -1561,11 +1911,13 @@ func addRefsToNavItems(navItems []crd.ChromeNavItem, bundleID string, frontendID
 	return res
 }
 
-func setupBundlesData(feList *crd.FrontendList, feEnvironment crd.FrontendEnvironment) ([]crd.FrontendBundlesGenerated, []string, error) {
+// setupBundlesData generates the bundle navigation. Next to the bundles it returns the bundle segments
+// that reference an unknown bundle and the segment references that could not be resolved.
+func setupBundlesData(feList *crd.FrontendList, feEnvironment crd.FrontendEnvironment) ([]crd.FrontendBundlesGenerated, []skippedConfigItem, []skippedConfigItem, error) {
 	bundles := []crd.FrontendBundlesGenerated{}
 	if feEnvironment.Spec.Bundles == nil {
 		// skip if we do not have bundles in fe environment
-		return bundles, []string{}, nil
+		return bundles, []skippedConfigItem{}, []skippedConfigItem{}, nil
 	}
 
 	// fill the nav segment cache
@@ -1585,7 +1937,10 @@ func setupBundlesData(feList *crd.FrontendList, feEnvironment crd.FrontendEnviro
 		}
 	}
 
-	skippedNavItemsMap := make(map[string][]string)
+	navRefIssues := []skippedConfigItem{}
+	refs := navRefsContext{issues: &navRefIssues}
+
+	skippedNavItemsMap := make(map[string][]skippedConfigItem)
 	bundleNavSegmentMap := make(map[string][]crd.BundleSegment)
 	for _, frontend := range feList.Items {
 		if frontend.Spec.FeoConfigEnabled && frontend.Spec.BundleSegments != nil {
@@ -1593,7 +1948,13 @@ func setupBundlesData(feList *crd.FrontendList, feEnvironment crd.FrontendEnviro
 				navItemsWithRefs := addRefsToNavItems(*bundleNavSegment.NavItems, bundleNavSegment.BundleID, frontend.Name, bundleNavSegment.SegmentID)
 				bundleNavSegment.NavItems = &navItemsWithRefs
 				bundleNavSegmentMap[bundleNavSegment.BundleID] = append(bundleNavSegmentMap[bundleNavSegment.BundleID], *bundleNavSegment)
-				skippedNavItemsMap[bundleNavSegment.BundleID] = append(skippedNavItemsMap[bundleNavSegment.BundleID], getNavItemPath(frontend.Name, bundleNavSegment.BundleID, bundleNavSegment.SegmentID))
+				skippedNavItemsMap[bundleNavSegment.BundleID] = append(skippedNavItemsMap[bundleNavSegment.BundleID], skippedConfigItem{
+					FrontendName:      frontend.Name,
+					FrontendNamespace: frontend.Namespace,
+					Kind:              "bundle segment",
+					ID:                getNavItemPath(frontend.Name, bundleNavSegment.BundleID, bundleNavSegment.SegmentID),
+					Reason:            fmt.Sprintf("unknown bundle ID %q", bundleNavSegment.BundleID),
+				})
 			}
 		}
 	}
@@ -1617,9 +1978,9 @@ func setupBundlesData(feList *crd.FrontendList, feEnvironment crd.FrontendEnviro
 			}
 		}
 		// fill the nav refs before adding the bundle
-		navItems, err := fillNavRefsTree(navItems, navSegmentsCache, 0)
+		navItems, err := fillNavRefsTree(navItems, navSegmentsCache, 0, refs)
 		if err != nil {
-			return bundles, []string{}, err
+			return bundles, []skippedConfigItem{}, []skippedConfigItem{}, err
 		}
 
 		navItems = filterUnknownNavRefs(navItems)
@@ -1632,32 +1993,24 @@ func setupBundlesData(feList *crd.FrontendList, feEnvironment crd.FrontendEnviro
 		bundles = append(bundles, newBundle)
 	}
 
-	skippedNavItems := []string{}
+	skippedNavItems := []skippedConfigItem{}
 	for _, skipped := range skippedNavItemsMap {
 		skippedNavItems = append(skippedNavItems, skipped...)
 	}
+	sort.Slice(skippedNavItems, func(i, j int) bool {
+		return skippedNavItems[i].ID < skippedNavItems[j].ID
+	})
 
-	return bundles, skippedNavItems, nil
-}
-
-func (r *FrontendReconciliation) setupBundleData(_ *v1.ConfigMap, _ map[string]crd.Frontend) error {
-	bundleList := &crd.BundleList{}
-
-	if err := r.FRE.Client.List(r.Ctx, bundleList, client.MatchingFields{"spec.envName": r.Frontend.Spec.EnvName}); err != nil {
-		return err
+	// nav segments are referenced by frontend name only
+	frontendNamespaces := make(map[string]string)
+	for _, frontend := range feList.Items {
+		frontendNamespaces[frontend.Name] = frontend.Namespace
 	}
-
-	keys := []string{}
-	nBundleMap := map[string]crd.Bundle{}
-	for _, bundle := range bundleList.Items {
-		keys = append(keys, bundle.Name)
-		nBundleMap[bundle.Name] = bundle
+	for i := range navRefIssues {
+		navRefIssues[i].FrontendNamespace = frontendNamespaces[navRefIssues[i].FrontendName]
 	}
 
-	sort.Strings(keys)
-
-	// TODO: Update reconcile to use the position based navigation for bundles
-	return nil
+	return bundles, skippedNavItems, navRefIssues, nil
 }
 
 func createConfigmapHash(hashBase []map[string]string) (string, error) {
@@ -1712,301 +2065,6 @@ func setupAPISpecs(feList *crd.FrontendList) []crd.APISpecInfo {
 	return allSpecs
 }
 
-// setupConfigMaps will create configmaps for the various config json
-// files, including fed-modules.json and the various bundle json files
-func (r *FrontendReconciliation) setupConfigMaps() ([]*v1.ConfigMap, error) {
-	// Will need to interact directly with the client here, and not the cache because
-	// we need to read ALL the Frontend CRDs in the Env that we care about
-
-	// Create a frontend list
-	frontendList := &crd.FrontendList{}
-
-	// Populate the frontendlist by looking for all frontends in our env
-	if err := r.FRE.Client.List(r.Ctx, frontendList, client.MatchingFields{"spec.envName": r.Frontend.Spec.EnvName}); err != nil {
-		return []*v1.ConfigMap{}, err
-	}
-
-	configMaps := []*v1.ConfigMap{}
-
-	// default config map, should be always created
-	defaultNN := types.NamespacedName{
-		Name:      r.Frontend.Spec.EnvName,
-		Namespace: r.Frontend.Namespace,
-	}
-
-	defaultWidgetNN := types.NamespacedName{
-		Name:      r.Frontend.Spec.EnvName + "-widget-registry",
-		Namespace: r.Frontend.Namespace,
-	}
-
-	defaultBaseLayoutNN := types.NamespacedName{
-		Name:      r.Frontend.Spec.EnvName + "-base-widget-dashboard-templates",
-		Namespace: r.Frontend.Namespace,
-	}
-
-	// TODO: The conntext map should be configured via env variable from app interface
-	frontendCFGContextName := "feo-context-cfg"
-	widgetsCFGContextName := "widget-registry-cfg"
-	baseLayoutsCFGContextName := "base-widget-dashboard-templates-cfg"
-	if strings.Contains(r.Frontend.Namespace, "beta") {
-		// separate stable and beta config map names
-		// quick patch to see if we can separate the configurations
-		frontendCFGContextName += "-beta"
-	}
-	additionalNN := []types.NamespacedName{}
-	for _, n := range r.FrontendEnvironment.Spec.TargetNamespaces {
-		additionalNN = append(additionalNN, types.NamespacedName{
-			Name:      frontendCFGContextName,
-			Namespace: n,
-		})
-	}
-
-	defaultCfgMap, err := r.createConfigMap(defaultNN, frontendList, true, nil)
-	if err != nil {
-		return []*v1.ConfigMap{}, err
-	}
-	configMaps = append(configMaps, defaultCfgMap)
-	widgetCfgMap, err := r.createWidgetRegistryConfigMap(defaultWidgetNN, frontendList, true, nil)
-	if err != nil {
-		return []*v1.ConfigMap{}, err
-	}
-	configMaps = append(configMaps, widgetCfgMap)
-
-	baseWidgetDashboardTemplatesConfigMap, err := r.createBaseWidgetDashboardTemplatesConfigMap(defaultBaseLayoutNN, frontendList, true, nil)
-	if err != nil {
-		return configMaps, err
-	}
-	configMaps = append(configMaps, baseWidgetDashboardTemplatesConfigMap)
-
-	for _, nn := range additionalNN {
-		widgetsNN := types.NamespacedName{
-			Name:      widgetsCFGContextName,
-			Namespace: nn.Namespace,
-		}
-		baseLayoutsNN := types.NamespacedName{
-			Name:      baseLayoutsCFGContextName,
-			Namespace: nn.Namespace,
-		}
-		_, err = r.createConfigMap(nn, frontendList, true, defaultCfgMap)
-		if err != nil {
-			return configMaps, err
-		}
-		_, err = r.createWidgetRegistryConfigMap(widgetsNN, frontendList, true, widgetCfgMap)
-		if err != nil {
-			return configMaps, err
-		}
-
-		_, err = r.createBaseWidgetDashboardTemplatesConfigMap(baseLayoutsNN, frontendList, true, baseWidgetDashboardTemplatesConfigMap)
-		if err != nil {
-			return configMaps, err
-		}
-	}
-
-	return configMaps, err
-}
-
-func (r *FrontendReconciliation) createBaseWidgetDashboardTemplatesConfigMap(nn types.NamespacedName, frontendList *crd.FrontendList, markForRestart bool, sourceConfigMap *v1.ConfigMap) (*v1.ConfigMap, error) {
-	cfgMap := &v1.ConfigMap{}
-	if err := r.Cache.Create(CoreConfig, nn, cfgMap); err != nil {
-		return cfgMap, err
-	}
-
-	cacheMap := make(map[string]crd.Frontend)
-	for _, frontend := range frontendList.Items {
-		cacheMap[frontend.Name] = frontend
-	}
-
-	cfgMap = r.setupConfigMapWithLabels(nn, markForRestart)
-
-	if sourceConfigMap != nil {
-		r.Log.Info("Using data from existing config map", "targetConfigMapName", cfgMap.Name, "targetConfigMapNamespace", cfgMap.Namespace, "sourceConfigMapName", sourceConfigMap.Name, "sourceConfigMapNamespace", sourceConfigMap.Namespace)
-		cfgMap.Data = sourceConfigMap.Data
-	} else {
-		baseWidgetDashboardTemplates := setupBaseWidgetDashboardTemplates(frontendList)
-
-		baseWidgetDashboardTemplatesJSONData, err := json.Marshal(baseWidgetDashboardTemplates)
-		if err != nil {
-			return cfgMap, err
-		}
-
-		cfgMap.Data = map[string]string{}
-		if len(baseWidgetDashboardTemplates) > 0 {
-			cfgMap.Data["base-widget-dashboard-templates.json"] = string(baseWidgetDashboardTemplatesJSONData)
-		}
-	}
-
-	if err := r.Cache.Update(CoreConfig, cfgMap); err != nil {
-		return cfgMap, err
-	}
-
-	return cfgMap, nil
-}
-
-func (r *FrontendReconciliation) createWidgetRegistryConfigMap(nn types.NamespacedName, frontendList *crd.FrontendList, markForRestart bool, sourceConfigMap *v1.ConfigMap) (*v1.ConfigMap, error) {
-	cfgMap := &v1.ConfigMap{}
-	if err := r.Cache.Create(CoreConfig, nn, cfgMap); err != nil {
-		return cfgMap, err
-	}
-
-	// Apply the common setup (annotations and labels)
-	cfgMap = r.setupConfigMapWithLabels(nn, markForRestart)
-
-	cacheMap := make(map[string]crd.Frontend)
-	for _, frontend := range frontendList.Items {
-		cacheMap[frontend.Name] = frontend
-	}
-
-	if sourceConfigMap != nil {
-		r.Log.Info("Using data from existing config map", "targetConfigMapName", cfgMap.Name, "targetConfigMapNamespace", cfgMap.Namespace, "sourceConfigMapName", sourceConfigMap.Name, "sourceConfigMapNamespace", sourceConfigMap.Namespace)
-		cfgMap.Data = sourceConfigMap.Data
-	} else {
-		widgetRegistry := setupWidgetRegistry(frontendList)
-
-		widgetRegistryJSONData, err := json.Marshal(widgetRegistry)
-		if err != nil {
-			return cfgMap, err
-		}
-
-		cfgMap.Data = map[string]string{}
-		if len(widgetRegistry) > 0 {
-			cfgMap.Data["widget-registry.json"] = string(widgetRegistryJSONData)
-		}
-	}
-
-	if err := r.Cache.Update(CoreConfig, cfgMap); err != nil {
-		return cfgMap, err
-	}
-
-	return cfgMap, nil
-}
-
-func (r *FrontendReconciliation) createConfigMap(nn types.NamespacedName, frontendList *crd.FrontendList, markForRestart bool, sourceConfigMap *v1.ConfigMap) (*v1.ConfigMap, error) {
-	cfgMap := &v1.ConfigMap{}
-	if err := r.Cache.Create(CoreConfig, nn, cfgMap); err != nil {
-		return cfgMap, err
-	}
-
-	// Apply the common setup (annotations and labels)
-	cfgMap = r.setupConfigMapWithLabels(nn, markForRestart)
-
-	// Create a map of frontend names to frontend objects
-	cacheMap := make(map[string]crd.Frontend)
-	for _, frontend := range frontendList.Items {
-		cacheMap[frontend.Name] = frontend
-	}
-
-	if err := r.populateConfigMap(cfgMap, cacheMap, frontendList, sourceConfigMap); err != nil {
-		return cfgMap, err
-	}
-
-	if err := r.Cache.Update(CoreConfig, cfgMap); err != nil {
-		return cfgMap, err
-	}
-	return cfgMap, nil
-}
-
-func (r *FrontendReconciliation) populateConfigMap(cfgMap *v1.ConfigMap, cacheMap map[string]crd.Frontend, feList *crd.FrontendList, sourceConfigMap *v1.ConfigMap) error {
-	cfgMap.SetOwnerReferences([]metav1.OwnerReference{r.FrontendEnvironment.MakeOwnerReference()})
-	cfgMap.Data = map[string]string{}
-
-	if sourceConfigMap != nil {
-		r.Log.Info("Using data from existing config map", "targetConfigMapName", cfgMap.Name, "targetConfigMapNamespace", cfgMap.Namespace, "sourceConfigMapName", sourceConfigMap.Name, "sourceConfigMapNamespace", sourceConfigMap.Namespace)
-		cfgMap.Data = sourceConfigMap.Data
-		return nil
-	}
-
-	if r.FrontendEnvironment.Spec.GenerateNavJSON {
-		if err := r.setupBundleData(cfgMap, cacheMap); err != nil {
-			return err
-		}
-	}
-
-	fedModules := make(map[string]crd.FedModule)
-	if err := setupFedModules(r.FrontendEnvironment, feList, fedModules); err != nil {
-		return fmt.Errorf("error setting up fedModules: %w", err)
-	}
-
-	searchIndex := setupSearchIndex(feList)
-
-	serviceCategories, skippedTiles := setupServiceTilesData(feList, *r.FrontendEnvironment)
-
-	bundles, skippedBundles, err := setupBundlesData(feList, *r.FrontendEnvironment)
-	if err != nil {
-		return err
-	}
-
-	// Collect API specs from all frontends
-	apiSpecs := setupAPISpecs(feList)
-
-	// Log information about collected API specs for debugging
-	if len(apiSpecs) > 0 {
-		r.Log.Info("Collected API specs for config map", "specCount", len(apiSpecs))
-	}
-
-	fedModulesJSONData, err := json.Marshal(fedModules)
-	if err != nil {
-		return err
-	}
-
-	searchIndexJSONData, err := json.Marshal(searchIndex)
-
-	if err != nil {
-		return err
-	}
-
-	serviceCategoriesJSONData, err := json.Marshal(serviceCategories)
-
-	if err != nil {
-		return err
-	}
-
-	bundlesJSONData, err := json.Marshal(bundles)
-	if err != nil {
-		return err
-	}
-
-	apiSpecsJSONData, err := json.Marshal(apiSpecs)
-	if err != nil {
-		return err
-	}
-
-	if len(skippedTiles) > 0 {
-		r.Log.Info(fmt.Sprintf("Unable to find service categories for tiles: %s", strings.Join(skippedTiles, ",")))
-	}
-
-	if len(skippedBundles) > 0 {
-		r.Log.Info(fmt.Sprintf("Unable to find bundle for nav items: %s", strings.Join(skippedBundles, ",")))
-	}
-
-	cfgMap.Data["fed-modules.json"] = string(fedModulesJSONData)
-	cfgMap.Data["Caddyfile"] = caddyFileTemplate
-	if len(searchIndex) > 0 {
-		cfgMap.Data["search-index.json"] = string(searchIndexJSONData)
-	}
-
-	if len(serviceCategories) > 0 {
-		cfgMap.Data["service-tiles.json"] = string(serviceCategoriesJSONData)
-	}
-
-	if len(bundles) > 0 {
-		cfgMap.Data["bundles.json"] = string(bundlesJSONData)
-	}
-
-	if len(apiSpecs) > 0 {
-		cfgMap.Data["api-specs.json"] = string(apiSpecsJSONData)
-	}
-
-	// Generate SSO configuration
-	ssoConfig := setupSSOConfig(r.FrontendEnvironment)
-	ssoConfigJSONData, err := json.Marshal(ssoConfig)
-	if err != nil {
-		return err
-	}
-	cfgMap.Data["sso-config.json"] = string(ssoConfigJSONData)
-
-	return nil
-}
-
 func (r *FrontendReconciliation) createServiceMonitor() error {
 
 	// the monitor mode will default to "app-interface"
@@ -0,0 +1,299 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	resCache "github.com/RedHatInsights/rhc-osdk-utils/resourceCache"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EnvironmentConfigReconciliation generates the config maps of a FrontendEnvironment from
+// all of its Frontends
+type EnvironmentConfigReconciliation struct {
+	Log                 logr.Logger
+	Cache               resCache.ObjectCache
+	Client              client.Client
+	Ctx                 context.Context
+	FrontendEnvironment *crd.FrontendEnvironment
+	Frontends           *crd.FrontendList
+	// ConfigurationWarnings lists the parts of each Frontend's config that were left out
+	// of the generated config
+	ConfigurationWarnings map[types.NamespacedName][]string
+	// NavigationReferenceIssues lists the unresolved segment references of every
+	// feoConfigEnabled Frontend in the environment
+	NavigationReferenceIssues map[types.NamespacedName][]string
+}
+
+func (r *EnvironmentConfigReconciliation) run() error {
+	if len(r.Frontends.Items) == 0 {
+		r.Log.Info("No Frontends in environment, skipping config generation")
+		return nil
+	}
+	return r.setupConfigMaps()
+}
+
+// setupConfigMapWithLabels creates a ConfigMap with the specified name and namespace,
+// applies labels, and optionally sets the recycle annotation for pod restart
+func (r *EnvironmentConfigReconciliation) setupConfigMapWithLabels(nn types.NamespacedName, markForRestart bool) *v1.ConfigMap {
+	cfgMap := &v1.ConfigMap{}
+	if markForRestart {
+		if cfgMap.Annotations == nil {
+			cfgMap.Annotations = map[string]string{}
+		}
+		// Flag to trigger pod restart if config map changes
+		cfgMap.Annotations["qontract.recycle"] = "true"
+	}
+
+	labels := r.FrontendEnvironment.GetLabels()
+	labler := utils.GetCustomLabeler(labels, nn, r.FrontendEnvironment)
+	labler(cfgMap)
+
+	return cfgMap
+}
+
+func (r *EnvironmentConfigReconciliation) setupBundleData(_ *v1.ConfigMap, _ map[string]crd.Frontend) error {
+	bundleList := &crd.BundleList{}
+
+	if err := r.Client.List(r.Ctx, bundleList, client.MatchingFields{"spec.envName": r.FrontendEnvironment.Name}); err != nil {
+		return err
+	}
+
+	keys := []string{}
+	nBundleMap := map[string]crd.Bundle{}
+	for _, bundle := range bundleList.Items {
+		keys = append(keys, bundle.Name)
+		nBundleMap[bundle.Name] = bundle
+	}
+
+	sort.Strings(keys)
+
+	// TODO: Update reconcile to use the position based navigation for bundles
+	return nil
+}
+
+// setupConfigMaps will create configmaps for the various config json
+// files, including fed-modules.json and the various bundle json files.
+// The config is generated once and copied to the namespace of every
+// Frontend in the environment and to the TargetNamespaces.
+func (r *EnvironmentConfigReconciliation) setupConfigMaps() error {
+	envName := r.FrontendEnvironment.Name
+
+	// TODO: The conntext map should be configured via env variable from app interface
+	namespaces := []string{}
+	for _, frontend := range r.Frontends.Items {
+		if !slices.Contains(namespaces, frontend.Namespace) {
+			namespaces = append(namespaces, frontend.Namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	frontendCFGContextNames := frontendContextConfigMapNames(r.Frontends.Items)
+
+	// The first namespace renders the config, the other namespaces copy it
+	var defaultCfgMap, widgetCfgMap, baseWidgetDashboardTemplatesConfigMap *v1.ConfigMap
+	for _, namespace := range namespaces {
+		cfgMap, err := r.createConfigMap(types.NamespacedName{Name: envName, Namespace: namespace}, true, defaultCfgMap)
+		if err != nil {
+			return err
+		}
+		widgetRegistryCfgMap, err := r.createWidgetRegistryConfigMap(types.NamespacedName{Name: envName + WidgetRegistryConfigMapSuffix, Namespace: namespace}, true, widgetCfgMap)
+		if err != nil {
+			return err
+		}
+		baseLayoutsCfgMap, err := r.createBaseWidgetDashboardTemplatesConfigMap(types.NamespacedName{Name: envName + BaseWidgetDashboardTemplatesConfigMapSuffix, Namespace: namespace}, true, baseWidgetDashboardTemplatesConfigMap)
+		if err != nil {
+			return err
+		}
+		if defaultCfgMap == nil {
+			defaultCfgMap = cfgMap
+			widgetCfgMap = widgetRegistryCfgMap
+			baseWidgetDashboardTemplatesConfigMap = baseLayoutsCfgMap
+		}
+	}
+
+	for _, n := range r.FrontendEnvironment.Spec.TargetNamespaces {
+		for _, frontendCFGContextName := range frontendCFGContextNames {
+			if _, err := r.createConfigMap(types.NamespacedName{Name: frontendCFGContextName, Namespace: n}, true, defaultCfgMap); err != nil {
+				return err
+			}
+		}
+		if _, err := r.createWidgetRegistryConfigMap(types.NamespacedName{Name: WidgetRegistryContextConfigMapName, Namespace: n}, true, widgetCfgMap); err != nil {
+			return err
+		}
+		if _, err := r.createBaseWidgetDashboardTemplatesConfigMap(types.NamespacedName{Name: BaseWidgetDashboardTemplatesContextName, Namespace: n}, true, baseWidgetDashboardTemplatesConfigMap); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// frontendContextConfigMapNames returns the names of the context config maps copied to the
+// target namespaces, the frontends in beta namespaces get their own copy.
+func frontendContextConfigMapNames(frontends []crd.Frontend) []string {
+	names := []string{}
+	for _, frontend := range frontends {
+		name := FrontendContextConfigMapName
+		if strings.Contains(frontend.Namespace, "beta") {
+			// separate stable and beta config map names
+			// quick patch to see if we can separate the configurations
+			name += "-beta"
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *EnvironmentConfigReconciliation) createBaseWidgetDashboardTemplatesConfigMap(nn types.NamespacedName, markForRestart bool, sourceConfigMap *v1.ConfigMap) (*v1.ConfigMap, error) {
+	cfgMap := &v1.ConfigMap{}
+	if err := r.Cache.Create(CoreConfig, nn, cfgMap); err != nil {
+		return cfgMap, err
+	}
+
+	cfgMap = r.setupConfigMapWithLabels(nn, markForRestart)
+
+	if sourceConfigMap != nil {
+		r.Log.Info("Using data from existing config map", "targetConfigMapName", cfgMap.Name, "targetConfigMapNamespace", cfgMap.Namespace, "sourceConfigMapName", sourceConfigMap.Name, "sourceConfigMapNamespace", sourceConfigMap.Namespace)
+		cfgMap.Data = sourceConfigMap.Data
+	} else {
+		data, err := renderBaseWidgetDashboardTemplatesData(r.Frontends)
+		if err != nil {
+			return cfgMap, err
+		}
+		cfgMap.Data = data
+	}
+
+	if err := r.Cache.Update(CoreConfig, cfgMap); err != nil {
+		return cfgMap, err
+	}
+
+	return cfgMap, nil
+}
+
+func (r *EnvironmentConfigReconciliation) createWidgetRegistryConfigMap(nn types.NamespacedName, markForRestart bool, sourceConfigMap *v1.ConfigMap) (*v1.ConfigMap, error) {
+	cfgMap := &v1.ConfigMap{}
+	if err := r.Cache.Create(CoreConfig, nn, cfgMap); err != nil {
+		return cfgMap, err
+	}
+
+	// Apply the common setup (annotations and labels)
+	cfgMap = r.setupConfigMapWithLabels(nn, markForRestart)
+
+	if sourceConfigMap != nil {
+		r.Log.Info("Using data from existing config map", "targetConfigMapName", cfgMap.Name, "targetConfigMapNamespace", cfgMap.Namespace, "sourceConfigMapName", sourceConfigMap.Name, "sourceConfigMapNamespace", sourceConfigMap.Namespace)
+		cfgMap.Data = sourceConfigMap.Data
+	} else {
+		data, err := renderWidgetRegistryData(r.Frontends)
+		if err != nil {
+			return cfgMap, err
+		}
+		cfgMap.Data = data
+	}
+
+	if err := r.Cache.Update(CoreConfig, cfgMap); err != nil {
+		return cfgMap, err
+	}
+
+	return cfgMap, nil
+}
+
+func (r *EnvironmentConfigReconciliation) createConfigMap(nn types.NamespacedName, markForRestart bool, sourceConfigMap *v1.ConfigMap) (*v1.ConfigMap, error) {
+	cfgMap := &v1.ConfigMap{}
+	if err := r.Cache.Create(CoreConfig, nn, cfgMap); err != nil {
+		return cfgMap, err
+	}
+
+	// Apply the common setup (annotations and labels)
+	cfgMap = r.setupConfigMapWithLabels(nn, markForRestart)
+
+	// Create a map of frontend names to frontend objects
+	cacheMap := make(map[string]crd.Frontend)
+	for _, frontend := range r.Frontends.Items {
+		cacheMap[frontend.Name] = frontend
+	}
+
+	if err := r.populateConfigMap(cfgMap, cacheMap, sourceConfigMap); err != nil {
+		return cfgMap, err
+	}
+
+	if err := r.Cache.Update(CoreConfig, cfgMap); err != nil {
+		return cfgMap, err
+	}
+	return cfgMap, nil
+}
+
+func (r *EnvironmentConfigReconciliation) populateConfigMap(cfgMap *v1.ConfigMap, cacheMap map[string]crd.Frontend, sourceConfigMap *v1.ConfigMap) error {
+	cfgMap.SetOwnerReferences([]metav1.OwnerReference{r.FrontendEnvironment.MakeOwnerReference()})
+	cfgMap.Data = map[string]string{}
+
+	if sourceConfigMap != nil {
+		r.Log.Info("Using data from existing config map", "targetConfigMapName", cfgMap.Name, "targetConfigMapNamespace", cfgMap.Namespace, "sourceConfigMapName", sourceConfigMap.Name, "sourceConfigMapNamespace", sourceConfigMap.Namespace)
+		cfgMap.Data = sourceConfigMap.Data
+		return nil
+	}
+
+	if r.FrontendEnvironment.Spec.GenerateNavJSON {
+		if err := r.setupBundleData(cfgMap, cacheMap); err != nil {
+			return err
+		}
+	}
+
+	rendered := &RenderedConfig{}
+	data, err := renderConfigData(r.FrontendEnvironment, r.Frontends, rendered)
+	if err != nil {
+		return err
+	}
+
+	// Log information about collected API specs for debugging
+	if _, ok := data["api-specs.json"]; ok {
+		r.Log.Info("Collected API specs for config map", "configMapName", cfgMap.Name)
+	}
+
+	if len(rendered.skippedTiles) > 0 {
+		r.Log.Info(fmt.Sprintf("Unable to find service categories for tiles: %s", skippedConfigItemIDs(rendered.skippedTiles)))
+	}
+
+	if len(rendered.skippedBundleSegments) > 0 {
+		r.Log.Info(fmt.Sprintf("Unable to find bundle for nav items: %s", skippedConfigItemIDs(rendered.skippedBundleSegments)))
+	}
+
+	if len(rendered.skippedCaddyfiles) > 0 {
+		r.Log.Info(fmt.Sprintf("Unable to render Caddyfiles: %s", skippedConfigItemIDs(rendered.skippedCaddyfiles)))
+	}
+
+	r.ConfigurationWarnings = map[types.NamespacedName][]string{}
+	r.NavigationReferenceIssues = map[types.NamespacedName][]string{}
+	for _, frontend := range r.Frontends.Items {
+		nn := types.NamespacedName{Name: frontend.Name, Namespace: frontend.Namespace}
+		r.ConfigurationWarnings[nn] = []string{}
+		// segment refs can point to other frontends, so the result is tracked for all of them
+		if frontend.Spec.FeoConfigEnabled {
+			r.NavigationReferenceIssues[nn] = []string{}
+		}
+	}
+	for _, items := range [][]skippedConfigItem{rendered.skippedTiles, rendered.skippedBundleSegments, rendered.skippedCaddyfiles} {
+		for _, skipped := range items {
+			nn := types.NamespacedName{Name: skipped.FrontendName, Namespace: skipped.FrontendNamespace}
+			r.ConfigurationWarnings[nn] = append(r.ConfigurationWarnings[nn], skipped.String())
+		}
+	}
+	for _, issue := range rendered.navRefIssues {
+		nn := types.NamespacedName{Name: issue.FrontendName, Namespace: issue.FrontendNamespace}
+		r.NavigationReferenceIssues[nn] = append(r.NavigationReferenceIssues[nn], issue.String())
+	}
+
+	cfgMap.Data = data
+	return nil
+}
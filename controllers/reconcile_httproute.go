@@ -0,0 +1,248 @@
+/*
+Copyright 2025 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+)
+
+// validateGatewayRouting checks the environment can be exposed with HTTPRoutes. Environments
+// with a whitelist are kept on Ingress objects by UsesGatewayAPI, the core HTTPRoute API has no
+// client address match.
+func validateGatewayRouting(frontendEnvironment *crd.FrontendEnvironment) error {
+	if frontendEnvironment.Spec.Gateway == nil || frontendEnvironment.Spec.Gateway.Name == "" {
+		return fmt.Errorf("gateway must be specified in FrontendEnvironment spec when routingMode is %s", crd.RoutingModeGatewayAPI)
+	}
+	return nil
+}
+
+// buildHTTPRouteSpec routes the path prefixes of the host to the service port through the
+// environment gateway, the httpHeaders of the environment are set on the responses
+func buildHTTPRouteSpec(gateway *crd.GatewayParentRef, host string, paths []string, serviceName string, port int32, httpHeaders map[string]string) gatewayv1.HTTPRouteSpec {
+	group := gatewayv1.Group(gatewayv1.GroupName)
+	kind := gatewayv1.Kind("Gateway")
+	parentRef := gatewayv1.ParentReference{
+		Group: &group,
+		Kind:  &kind,
+		Name:  gatewayv1.ObjectName(gateway.Name),
+	}
+	if gateway.Namespace != "" {
+		namespace := gatewayv1.Namespace(gateway.Namespace)
+		parentRef.Namespace = &namespace
+	}
+	if gateway.SectionName != "" {
+		sectionName := gatewayv1.SectionName(gateway.SectionName)
+		parentRef.SectionName = &sectionName
+	}
+
+	// Set every field the API server defaults, so the desired spec compares equal to the stored one
+	pathType := gatewayv1.PathMatchPathPrefix
+	matches := []gatewayv1.HTTPRouteMatch{}
+	for _, path := range paths {
+		matches = append(matches, gatewayv1.HTTPRouteMatch{
+			Path: &gatewayv1.HTTPPathMatch{
+				Type:  &pathType,
+				Value: utils.StringPtr(path),
+			},
+		})
+	}
+
+	backendGroup := gatewayv1.Group("")
+	backendKind := gatewayv1.Kind("Service")
+	backendPort := gatewayv1.PortNumber(port)
+	rule := gatewayv1.HTTPRouteRule{
+		Matches: matches,
+		BackendRefs: []gatewayv1.HTTPBackendRef{{
+			BackendRef: gatewayv1.BackendRef{
+				BackendObjectReference: gatewayv1.BackendObjectReference{
+					Group: &backendGroup,
+					Kind:  &backendKind,
+					Name:  gatewayv1.ObjectName(serviceName),
+					Port:  &backendPort,
+				},
+				Weight: utils.Int32Ptr(1),
+			},
+		}},
+	}
+
+	if len(httpHeaders) != 0 {
+		keys := make([]string, 0, len(httpHeaders))
+		for key := range httpHeaders {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		headers := []gatewayv1.HTTPHeader{}
+		for _, key := range keys {
+			headers = append(headers, gatewayv1.HTTPHeader{Name: gatewayv1.HTTPHeaderName(key), Value: httpHeaders[key]})
+		}
+		rule.Filters = []gatewayv1.HTTPRouteFilter{{
+			Type:                   gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+			ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{Set: headers},
+		}}
+	}
+
+	return gatewayv1.HTTPRouteSpec{
+		CommonRouteSpec: gatewayv1.CommonRouteSpec{
+			ParentRefs: []gatewayv1.ParentReference{parentRef},
+		},
+		Hostnames: []gatewayv1.Hostname{gatewayv1.Hostname(host)},
+		Rules:     []gatewayv1.HTTPRouteRule{rule},
+	}
+}
+
+// isOwnedBy returns true when one of the owner references of the object points to the UID
+func isOwnedBy(obj metav1.Object, uid types.UID) bool {
+	for _, ownerRef := range obj.GetOwnerReferences() {
+		if ownerRef.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteOwnedHTTPRoute removes the HTTPRoute left behind when an environment moves back to
+// Ingress. Clusters without the Gateway API CRDs can not have one.
+func deleteOwnedHTTPRoute(ctx context.Context, pClient client.Client, nn types.NamespacedName, ownerUID types.UID) error {
+	route := &gatewayv1.HTTPRoute{}
+	if err := pClient.Get(ctx, nn, route); err != nil {
+		if k8serr.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	if !isOwnedBy(route, ownerUID) {
+		return nil
+	}
+	return client.IgnoreNotFound(pClient.Delete(ctx, route))
+}
+
+func (r *FrontendReconciliation) createFrontendHTTPRoute() error {
+	if err := validateGatewayRouting(r.FrontendEnvironment); err != nil {
+		return err
+	}
+
+	route := &gatewayv1.HTTPRoute{}
+
+	nn := types.NamespacedName{
+		Name:      r.Frontend.Name,
+		Namespace: r.Frontend.Namespace,
+	}
+
+	if err := r.Cache.Create(FrontendHTTPRoute, nn, route); err != nil {
+		return err
+	}
+
+	labels := r.Frontend.GetLabels()
+	labler := utils.GetCustomLabeler(labels, nn, r.Frontend)
+	labler(route)
+
+	route.SetOwnerReferences([]metav1.OwnerReference{r.Frontend.MakeOwnerReference()})
+
+	serviceName := nn.Name
+	if r.Frontend.Spec.Image == "" {
+		serviceName = r.Frontend.Spec.Service
+	}
+
+	host := r.FrontendEnvironment.Spec.Hostname
+	if host == "" {
+		host = r.Frontend.Spec.EnvName
+	}
+
+	route.Spec = buildHTTPRouteSpec(r.FrontendEnvironment.Spec.Gateway, host, r.getFrontendPaths(), serviceName, 8000, r.FrontendEnvironment.Spec.HTTPHeaders)
+	if r.canaryActive() {
+		r.addCanaryBackendRef(&route.Spec)
+	}
+
+	return r.Cache.Update(FrontendHTTPRoute, route)
+}
+
+// reconcileHTTPRoute ensures the reverse proxy HTTPRoute exists and is up to date
+func (r *ReverseProxyReconciliation) reconcileHTTPRoute() error {
+	if err := validateGatewayRouting(r.FrontendEnvironment); err != nil {
+		return err
+	}
+
+	host := r.FrontendEnvironment.Spec.ReverseProxyHostname
+	if host == "" {
+		return fmt.Errorf("reverseProxyHostname must be specified in FrontendEnvironment spec when reverse proxy is enabled")
+	}
+
+	route := &gatewayv1.HTTPRoute{}
+	routeKey := types.NamespacedName{
+		Name:      "reverse-proxy",
+		Namespace: r.Namespace,
+	}
+
+	err := r.Client.Get(r.Ctx, routeKey, route)
+	if err != nil && !k8serr.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+
+	desired := route.DeepCopy()
+	desired.Name = routeKey.Name
+	desired.Namespace = routeKey.Namespace
+	desired.Labels = r.getReverseProxyLabels()
+	desired.SetOwnerReferences([]metav1.OwnerReference{r.getReverseProxyOwnerRef()})
+	desired.Spec = buildHTTPRouteSpec(r.FrontendEnvironment.Spec.Gateway, host, []string{"/"}, "reverse-proxy", ReverseProxyPort, nil)
+
+	if !exists {
+		r.Log.Info("Creating HTTPRoute for reverse proxy", "name", desired.Name, "namespace", desired.Namespace, "host", host)
+		return r.Client.Create(r.Ctx, desired)
+	}
+	if equality.Semantic.DeepEqual(route, desired) {
+		return nil
+	}
+
+	r.Log.Info("Updating reverse proxy HTTPRoute to match desired state")
+	return r.Client.Update(r.Ctx, desired)
+}
+
+// deleteReverseProxyIngress removes the reverse proxy Ingress once the environment is exposed
+// with an HTTPRoute or a Route
+func (r *ReverseProxyReconciliation) deleteReverseProxyIngress() error {
+	ingress := &networkingv1.Ingress{}
+	ingressKey := types.NamespacedName{
+		Name:      "reverse-proxy",
+		Namespace: r.Namespace,
+	}
+
+	if err := r.Client.Get(r.Ctx, ingressKey, ingress); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !isOwnedBy(ingress, r.FrontendEnvironment.UID) {
+		return nil
+	}
+
+	r.Log.Info("Deleting reverse proxy ingress replaced by the routing mode")
+	return client.IgnoreNotFound(r.Client.Delete(r.Ctx, ingress))
+}
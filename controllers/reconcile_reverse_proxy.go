@@ -25,6 +25,8 @@ import (
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policy "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -51,23 +53,69 @@ type ReverseProxyReconciliation struct {
 	Ctx                 context.Context
 	Namespace           string
 	FrontendEnvironment *crd.FrontendEnvironment
+
+	// credentialsHash of the object store credentials, it rolls the deployment when they rotate
+	credentialsHash string
 }
 
 // run executes the reverse proxy reconciliation logic
 func (r *ReverseProxyReconciliation) run() error {
+	// Reconcile the object store credentials the deployment reads
+	credentials, err := reconcileObjectStoreCredentials(r.Ctx, r.Client, r.FrontendEnvironment, r.Namespace)
+	if err != nil {
+		return err
+	}
+	r.credentialsHash, err = hashObjectStoreCredentials(credentials)
+	if err != nil {
+		return err
+	}
+
 	// Reconcile deployment
 	if err := r.reconcileDeployment(); err != nil {
 		return err
 	}
 
+	// Reconcile pod disruption budget
+	if err := r.reconcilePodDisruptionBudget(); err != nil {
+		return err
+	}
+
 	// Reconcile service
 	if err := r.reconcileService(); err != nil {
 		return err
 	}
 
-	// Reconcile ingress
-	if err := r.reconcileIngress(); err != nil {
-		return err
+	// Reconcile ingress, HTTPRoute or Route, whichever the environment is not exposed with is removed
+	switch {
+	case r.FrontendEnvironment.UsesGatewayAPI():
+		if err := r.reconcileHTTPRoute(); err != nil {
+			return err
+		}
+		if err := r.deleteReverseProxyIngress(); err != nil {
+			return err
+		}
+	case r.FrontendEnvironment.UsesRoutes():
+		if err := r.reconcileRoute(); err != nil {
+			return err
+		}
+		if err := r.deleteReverseProxyIngress(); err != nil {
+			return err
+		}
+	default:
+		if err := r.reconcileIngress(); err != nil {
+			return err
+		}
+	}
+	if !r.FrontendEnvironment.UsesGatewayAPI() {
+		routeKey := types.NamespacedName{Name: "reverse-proxy", Namespace: r.Namespace}
+		if err := deleteOwnedHTTPRoute(r.Ctx, r.Client, routeKey, r.FrontendEnvironment.UID); err != nil {
+			return err
+		}
+	}
+	if !r.FrontendEnvironment.UsesRoutes() {
+		if err := deleteOwnedRoutes(r.Ctx, r.Client, r.Namespace, r.getReverseProxyLabels(), r.FrontendEnvironment.UID); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -93,6 +141,48 @@ func (r *ReverseProxyReconciliation) reconcileDeployment() error {
 	return r.updateReverseProxyDeployment(deployment)
 }
 
+// reconcilePodDisruptionBudget ensures the reverse proxy pod disruption budget matches the
+// environment default, the budget is deleted when the default is disabled
+func (r *ReverseProxyReconciliation) reconcilePodDisruptionBudget() error {
+	pdb := &policy.PodDisruptionBudget{}
+	pdbKey := types.NamespacedName{
+		Name:      "reverse-proxy",
+		Namespace: r.Namespace,
+	}
+
+	err := r.Client.Get(r.Ctx, pdbKey, pdb)
+	if err != nil && !k8serr.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+
+	pdbConfig := resolvePodDisruptionBudgetConfig(r.FrontendEnvironment.Spec.DefaultPodDisruptionBudget)
+	if pdbConfig == nil {
+		if exists {
+			r.Log.Info("Deleting reverse proxy pod disruption budget")
+			return client.IgnoreNotFound(r.Client.Delete(r.Ctx, pdb))
+		}
+		return nil
+	}
+
+	desired := pdb.DeepCopy()
+	desired.Name = pdbKey.Name
+	desired.Namespace = pdbKey.Namespace
+	desired.Labels = r.getReverseProxyLabels()
+	desired.SetOwnerReferences([]metav1.OwnerReference{r.getReverseProxyOwnerRef()})
+	setPodDisruptionBudgetSpec(desired, pdbConfig, r.getReverseProxyLabels())
+
+	if !exists {
+		return r.Client.Create(r.Ctx, desired)
+	}
+	if equality.Semantic.DeepEqual(pdb, desired) {
+		return nil
+	}
+
+	r.Log.Info("Updating reverse proxy pod disruption budget to match desired state")
+	return r.Client.Update(r.Ctx, desired)
+}
+
 // reconcileService ensures the reverse proxy service exists and is up to date
 func (r *ReverseProxyReconciliation) reconcileService() error {
 	service := &v1.Service{}
@@ -351,6 +441,12 @@ func (r *ReverseProxyReconciliation) buildReverseProxyDeployment() (*apps.Deploy
 	// Create volumes for the deployment
 	volumes := r.createReverseProxyVolumes()
 
+	// Roll the pods when the object store credentials rotate, they only read them on start
+	var podAnnotations map[string]string
+	if r.credentialsHash != "" {
+		podAnnotations = map[string]string{"credentialsHash": r.credentialsHash}
+	}
+
 	// Create the deployment
 	deployment := &apps.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -375,7 +471,8 @@ func (r *ReverseProxyReconciliation) buildReverseProxyDeployment() (*apps.Deploy
 			},
 			Template: v1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
+					Labels:      labels,
+					Annotations: podAnnotations,
 				},
 				Spec: v1.PodSpec{
 					Containers: []v1.Container{container},
@@ -403,30 +500,23 @@ func (r *ReverseProxyReconciliation) createReverseProxyService() error {
 
 // createReverseProxyContainer configures the reverse proxy container
 func (r *ReverseProxyReconciliation) createReverseProxyContainer() (v1.Container, error) {
-	// Get object store configuration from environment variables (same as push cache)
-	objectStoreInfo, err := ExtractBucketConfigFromEnv()
+	// Get the object store configuration of the environment (same as push cache)
+	objectStoreInfo, err := ExtractBucketConfig(r.FrontendEnvironment)
 	if err != nil {
 		return v1.Container{}, err
 	}
 
 	// Get default values
 	minioPort := *objectStoreInfo.Port
-	minioEndpoint := *objectStoreInfo.Endpoint    // PUSHCACHE_AWS_ENDPOINT
-	bucketPathPrefix := *objectStoreInfo.Name     // PUSHCACHE_AWS_BUCKET_NAME
-	accessKeyID := *objectStoreInfo.AccessKey     // PUSHCACHE_AWS_ACCESS_KEY_ID
-	secretAccessKey := *objectStoreInfo.SecretKey // PUSHCACHE_AWS_SECRET_ACCESS_KEY
-	region := *objectStoreInfo.Region             // PUSHCACHE_AWS_REGION
-	var minioUpstreamURL string
-	var protocol string
-	// Construct upstream URL with appropriate scheme based on port
-	switch minioPort {
-	case "443":
+	minioEndpoint := *objectStoreInfo.Endpoint       // objectStore.endpoint or PUSHCACHE_AWS_ENDPOINT
+	bucketPathPrefix := objectStoreInfo.BucketPath() // objectStore.bucket or PUSHCACHE_AWS_BUCKET_NAME, and objectStore.pathPrefix
+	region := *objectStoreInfo.Region                // objectStore.region or PUSHCACHE_AWS_REGION
+	// Construct upstream URL with the scheme of the object store, TLS defaults to port 443 only
+	protocol := "http://"
+	if *objectStoreInfo.TLS {
 		protocol = "https://"
-	default:
-		// For non-standard ports, use http by default (local development)
-		protocol = "http://"
 	}
-	minioUpstreamURL = protocol + minioEndpoint + ":" + minioPort
+	minioUpstreamURL := protocol + minioEndpoint + ":" + minioPort
 
 	logLevel := r.FrontendEnvironment.Spec.ReverseProxyLogLevel
 	if logLevel == "" {
@@ -464,15 +554,9 @@ func (r *ReverseProxyReconciliation) createReverseProxyContainer() (v1.Container
 			Name:  "LOG_LEVEL",
 			Value: logLevel,
 		},
-		{
-			Name:  "PUSHCACHE_AWS_ACCESS_KEY_ID",
-			Value: accessKeyID,
-		},
-		{
-			Name:  "PUSHCACHE_AWS_SECRET_ACCESS_KEY",
-			Value: secretAccessKey,
-		},
 	}
+	// The credentials are read from the Secret managed by the operator
+	envVars = append(envVars, getObjectStoreCredentialsEnv(r.FrontendEnvironment)...)
 
 	// Add SSL environment variables if SSL is enabled (similar to main reconciler)
 	if r.FrontendEnvironment.Spec.SSL {
@@ -6,14 +6,19 @@ import (
 	"strings"
 	"testing"
 
+	routev1 "github.com/openshift/api/route/v1"
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policy "k8s.io/api/policy/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
 	"github.com/go-logr/logr"
@@ -239,8 +244,8 @@ func TestUpdateReverseProxyDeployment(t *testing.T) {
 				{Name: "BUCKET_PATH_PREFIX", Value: "frontend"},
 				{Name: "SPA_ENTRYPOINT_PATH", Value: "/index.html"},
 				{Name: "AWS_REGION", Value: "us-east-1"},
-				{Name: "PUSHCACHE_AWS_ACCESS_KEY_ID", Value: "test-access-key"},
-				{Name: "PUSHCACHE_AWS_SECRET_ACCESS_KEY", Value: "test-secret-key"},
+				{Name: "PUSHCACHE_AWS_ACCESS_KEY_ID", ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "test-env-pushcache-credentials"}, Key: "aws_access_key_id"}}},
+				{Name: "PUSHCACHE_AWS_SECRET_ACCESS_KEY", ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "test-env-pushcache-credentials"}, Key: "aws_secret_access_key"}}},
 				{Name: "LOG_LEVEL", Value: "DEBUG"},
 			},
 			expectUpdate:       false,
@@ -1282,6 +1287,9 @@ func TestReverseProxyReconciliation_FullReconciliation(t *testing.T) {
 	_ = v1.AddToScheme(scheme)
 	_ = apps.AddToScheme(scheme)
 	_ = networkingv1.AddToScheme(scheme)
+	_ = policy.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+	_ = routev1.Install(scheme)
 	_ = crd.AddToScheme(scheme)
 
 	// Create fake client
@@ -1376,4 +1384,100 @@ func TestReverseProxyReconciliation_FullReconciliation(t *testing.T) {
 	if container.Resources.Limits == nil {
 		t.Error("Expected resource limits to be set for scaling")
 	}
+
+	// Verify the object store credentials are read from the managed secret
+	for _, env := range container.Env {
+		if strings.HasPrefix(env.Name, "PUSHCACHE_AWS_") && (env.Value != "" || env.ValueFrom == nil || env.ValueFrom.SecretKeyRef.Name != "test-env-pushcache-credentials") {
+			t.Errorf("Expected %s to be read from the credentials secret, got %+v", env.Name, env)
+		}
+	}
+	secret := &v1.Secret{}
+	err = client.Get(context.Background(), types.NamespacedName{
+		Name:      "test-env-pushcache-credentials",
+		Namespace: "test-namespace",
+	}, secret)
+	if err != nil {
+		t.Fatalf("Failed to get the credentials secret: %v", err)
+	}
+	if string(secret.Data["aws_secret_access_key"]) != "test-secret-key" {
+		t.Errorf("Expected the credentials secret to hold the operator credentials, got %q", secret.Data)
+	}
+	credentialsHash := deployment.Spec.Template.Annotations["credentialsHash"]
+	if credentialsHash == "" {
+		t.Error("Expected the pod template to carry the credentials hash")
+	}
+
+	// Rotated credentials roll the pods
+	os.Setenv("PUSHCACHE_AWS_SECRET_ACCESS_KEY", "rotated-secret-key")
+	if err := reconciliation.run(); err != nil {
+		t.Fatalf("Reconciliation with rotated credentials failed: %v", err)
+	}
+	err = client.Get(context.Background(), types.NamespacedName{
+		Name:      "reverse-proxy",
+		Namespace: "test-namespace",
+	}, deployment)
+	if err != nil {
+		t.Fatalf("Failed to get updated deployment: %v", err)
+	}
+	if deployment.Spec.Template.Annotations["credentialsHash"] == credentialsHash {
+		t.Error("Expected rotated credentials to change the credentials hash")
+	}
+}
+
+func TestReverseProxyPodDisruptionBudget(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = crd.AddToScheme(scheme)
+	_ = policy.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		Build()
+
+	reconciliation := &ReverseProxyReconciliation{
+		Log:       logr.Discard(),
+		Recorder:  &record.FakeRecorder{},
+		Client:    fakeClient,
+		Ctx:       context.Background(),
+		Namespace: "test-namespace",
+		FrontendEnvironment: &crd.FrontendEnvironment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-env",
+			},
+		},
+	}
+	pdbKey := types.NamespacedName{Name: "reverse-proxy", Namespace: "test-namespace"}
+
+	if err := reconciliation.reconcilePodDisruptionBudget(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pdb := &policy.PodDisruptionBudget{}
+	if err := fakeClient.Get(context.Background(), pdbKey, pdb); err != nil {
+		t.Fatalf("expected the pod disruption budget to be created: %v", err)
+	}
+	if pdb.Spec.MaxUnavailable == nil || pdb.Spec.MaxUnavailable.IntValue() != 1 {
+		t.Errorf("expected maxUnavailable 1, got %v", pdb.Spec)
+	}
+	if pdb.Spec.Selector.MatchLabels["app"] != "reverse-proxy" || pdb.Spec.Selector.MatchLabels["environment"] != "test-env" {
+		t.Errorf("expected the budget to select the reverse proxy pods, got %v", pdb.Spec.Selector)
+	}
+
+	minAvailable := intstr.FromInt32(2)
+	reconciliation.FrontendEnvironment.Spec.DefaultPodDisruptionBudget = &crd.PodDisruptionBudgetConfig{MinAvailable: &minAvailable}
+	if err := reconciliation.reconcilePodDisruptionBudget(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := fakeClient.Get(context.Background(), pdbKey, pdb); err != nil {
+		t.Fatal(err)
+	}
+	if pdb.Spec.MinAvailable == nil || pdb.Spec.MinAvailable.IntValue() != 2 || pdb.Spec.MaxUnavailable != nil {
+		t.Errorf("expected minAvailable 2, got %v", pdb.Spec)
+	}
+
+	reconciliation.FrontendEnvironment.Spec.DefaultPodDisruptionBudget = &crd.PodDisruptionBudgetConfig{Disabled: true}
+	if err := reconciliation.reconcilePodDisruptionBudget(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := fakeClient.Get(context.Background(), pdbKey, pdb); !k8serr.IsNotFound(err) {
+		t.Errorf("expected the budget to be deleted, got %v", err)
+	}
 }
@@ -0,0 +1,300 @@
+/*
+Copyright 2025 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	routev1 "github.com/openshift/api/route/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+)
+
+// getRouteTLSConfig resolves the TLS of the Routes of an environment. Without a routeTLS
+// block the Routes reencrypt with ssl and terminate at the edge without.
+func getRouteTLSConfig(frontendEnvironment *crd.FrontendEnvironment) *routev1.TLSConfig {
+	termination := routev1.TLSTerminationEdge
+	if frontendEnvironment.Spec.SSL {
+		termination = routev1.TLSTerminationReencrypt
+	}
+	insecureEdgeTerminationPolicy := routev1.InsecureEdgeTerminationPolicyRedirect
+
+	if routeTLS := frontendEnvironment.Spec.RouteTLS; routeTLS != nil {
+		if routeTLS.Termination != "" {
+			termination = routev1.TLSTerminationType(routeTLS.Termination)
+		}
+		if routeTLS.InsecureEdgeTerminationPolicy != "" {
+			insecureEdgeTerminationPolicy = routev1.InsecureEdgeTerminationPolicyType(routeTLS.InsecureEdgeTerminationPolicy)
+		}
+	}
+
+	return &routev1.TLSConfig{
+		Termination:                   termination,
+		InsecureEdgeTerminationPolicy: insecureEdgeTerminationPolicy,
+	}
+}
+
+// getServingCA returns the CA that signed the service serving certificate in the secret, so
+// reencrypt Routes can verify the pods. It is empty until the certificate is issued.
+func getServingCA(ctx context.Context, pClient client.Client, nn types.NamespacedName) (string, error) {
+	secret := &v1.Secret{}
+	if err := pClient.Get(ctx, nn, secret); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	if ca, ok := secret.Data["ca.crt"]; ok {
+		return string(ca), nil
+	}
+
+	// The service CA appends its own certificate to the serving certificate chain
+	certificates := []*pem.Block{}
+	rest := secret.Data["tls.crt"]
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			certificates = append(certificates, block)
+		}
+	}
+	if len(certificates) < 2 {
+		return "", nil
+	}
+	return string(pem.EncodeToMemory(certificates[len(certificates)-1])), nil
+}
+
+// getRouteAnnotations returns the ingress annotations and the whitelist, both are read by the
+// OpenShift router from Routes as well
+func getRouteAnnotations(frontendEnvironment *crd.FrontendEnvironment) map[string]string {
+	annotations := map[string]string{}
+	for k, v := range frontendEnvironment.Spec.IngressAnnotations {
+		annotations[k] = v
+	}
+	if len(frontendEnvironment.Spec.Whitelist) != 0 {
+		annotations["haproxy.router.openshift.io/ip_whitelist"] = strings.Join(frontendEnvironment.Spec.Whitelist, " ")
+	}
+	return annotations
+}
+
+// buildRouteSpec routes the host and path to the named port of the service. Passthrough
+// Routes can not match on a path, the pods see the encrypted request.
+func buildRouteSpec(host, path, serviceName, targetPort string, tls *routev1.TLSConfig) routev1.RouteSpec {
+	if tls.Termination == routev1.TLSTerminationPassthrough {
+		path = ""
+	}
+	return routev1.RouteSpec{
+		Host: host,
+		Path: path,
+		To: routev1.RouteTargetReference{
+			Kind:   "Service",
+			Name:   serviceName,
+			Weight: utils.Int32Ptr(100),
+		},
+		Port: &routev1.RoutePort{
+			TargetPort: intstr.FromString(targetPort),
+		},
+		TLS:            tls,
+		WildcardPolicy: routev1.WildcardPolicyNone,
+	}
+}
+
+// deleteOwnedRoutes removes the Routes left behind when an environment moves away from
+// Routes. Clusters without the Route API can not have any.
+func deleteOwnedRoutes(ctx context.Context, pClient client.Client, namespace string, labels map[string]string, ownerUID types.UID) error {
+	routes := &routev1.RouteList{}
+	if err := pClient.List(ctx, routes, client.InNamespace(namespace), client.MatchingLabels(labels)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	for i := range routes.Items {
+		if !isOwnedBy(&routes.Items[i], ownerUID) {
+			continue
+		}
+		if err := pClient.Delete(ctx, &routes.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getRouteStatus reports whether the router admitted the Route and for which host
+func getRouteStatus(route *routev1.Route) crd.FrontendRouteStatus {
+	status := crd.FrontendRouteStatus{Name: route.Name}
+	for _, ingress := range route.Status.Ingress {
+		for _, condition := range ingress.Conditions {
+			if condition.Type != routev1.RouteAdmitted {
+				continue
+			}
+			if condition.Status == v1.ConditionTrue {
+				return crd.FrontendRouteStatus{Name: route.Name, Host: ingress.Host, Admitted: true}
+			}
+			status.Message = fmt.Sprintf("%s: %s", condition.Reason, condition.Message)
+		}
+	}
+	return status
+}
+
+// GetFrontendRouteStatus returns the admission of the Routes the Frontend controls
+func GetFrontendRouteStatus(ctx context.Context, pClient client.Client, frontend *crd.Frontend) ([]crd.FrontendRouteStatus, error) {
+	routes := &routev1.RouteList{}
+	if err := pClient.List(ctx, routes, client.InNamespace(frontend.Namespace), client.MatchingLabels{"frontend": frontend.Name}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var statuses []crd.FrontendRouteStatus
+	for i := range routes.Items {
+		if metav1.IsControlledBy(&routes.Items[i], frontend) {
+			statuses = append(statuses, getRouteStatus(&routes.Items[i]))
+		}
+	}
+	return statuses, nil
+}
+
+// getFrontendHosts returns the hosts of the Frontend Routes
+func (r *FrontendReconciliation) getFrontendHosts() []string {
+	if len(r.Frontend.Spec.Hosts) != 0 {
+		return r.Frontend.Spec.Hosts
+	}
+	host := r.FrontendEnvironment.Spec.Hostname
+	if host == "" {
+		host = r.Frontend.Spec.EnvName
+	}
+	return []string{host}
+}
+
+// createFrontendRoutes creates a Route for every host and path of the Frontend, a Route
+// only matches a single host and path
+func (r *FrontendReconciliation) createFrontendRoutes() error {
+	serviceName := r.Frontend.Name
+	if r.Frontend.Spec.Image == "" {
+		serviceName = r.Frontend.Spec.Service
+	}
+
+	tls := getRouteTLSConfig(r.FrontendEnvironment)
+	if tls.Termination == routev1.TLSTerminationReencrypt {
+		caNN := types.NamespacedName{Name: fmt.Sprintf("%s-%s", r.Frontend.Name, "cert"), Namespace: r.Frontend.Namespace}
+		destinationCA, err := getServingCA(r.Ctx, r.Client, caNN)
+		if err != nil {
+			return err
+		}
+		tls.DestinationCACertificate = destinationCA
+	}
+
+	paths := r.getFrontendPaths()
+	if tls.Termination == routev1.TLSTerminationPassthrough {
+		paths = []string{""}
+	}
+
+	i := 0
+	for _, host := range r.getFrontendHosts() {
+		for _, path := range paths {
+			nn := types.NamespacedName{
+				Name:      fmt.Sprintf("%s-%d", r.Frontend.Name, i),
+				Namespace: r.Frontend.Namespace,
+			}
+			i++
+
+			route := &routev1.Route{}
+			if err := r.Cache.Create(FrontendRoutes, nn, route); err != nil {
+				return err
+			}
+
+			labels := r.Frontend.GetLabels()
+			labler := utils.GetCustomLabeler(labels, nn, r.Frontend)
+			labler(route)
+
+			route.SetOwnerReferences([]metav1.OwnerReference{r.Frontend.MakeOwnerReference()})
+			route.SetAnnotations(getRouteAnnotations(r.FrontendEnvironment))
+			route.Spec = buildRouteSpec(host, path, serviceName, "public", tls.DeepCopy())
+			if r.canaryActive() {
+				r.addCanaryAlternateBackend(&route.Spec)
+			}
+
+			if err := r.Cache.Update(FrontendRoutes, route); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// reconcileRoute ensures the reverse proxy Route exists and is up to date
+func (r *ReverseProxyReconciliation) reconcileRoute() error {
+	host := r.FrontendEnvironment.Spec.ReverseProxyHostname
+	if host == "" {
+		return fmt.Errorf("reverseProxyHostname must be specified in FrontendEnvironment spec when reverse proxy is enabled")
+	}
+
+	tls := getRouteTLSConfig(r.FrontendEnvironment)
+	if tls.Termination == routev1.TLSTerminationReencrypt {
+		caNN := types.NamespacedName{Name: "reverse-proxy-cert", Namespace: r.Namespace}
+		destinationCA, err := getServingCA(r.Ctx, r.Client, caNN)
+		if err != nil {
+			return err
+		}
+		tls.DestinationCACertificate = destinationCA
+	}
+
+	route := &routev1.Route{}
+	routeKey := types.NamespacedName{
+		Name:      "reverse-proxy",
+		Namespace: r.Namespace,
+	}
+
+	err := r.Client.Get(r.Ctx, routeKey, route)
+	if err != nil && !k8serr.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+
+	desired := route.DeepCopy()
+	desired.Name = routeKey.Name
+	desired.Namespace = routeKey.Namespace
+	desired.Labels = r.getReverseProxyLabels()
+	desired.SetOwnerReferences([]metav1.OwnerReference{r.getReverseProxyOwnerRef()})
+	desired.SetAnnotations(getRouteAnnotations(r.FrontendEnvironment))
+	desired.Spec = buildRouteSpec(host, "/", "reverse-proxy", "http", tls)
+
+	if !exists {
+		r.Log.Info("Creating Route for reverse proxy", "name", desired.Name, "namespace", desired.Namespace, "host", host)
+		return r.Client.Create(r.Ctx, desired)
+	}
+	if equality.Semantic.DeepEqual(route, desired) {
+		return nil
+	}
+
+	r.Log.Info("Updating reverse proxy Route to match desired state")
+	return r.Client.Update(r.Ctx, desired)
+}
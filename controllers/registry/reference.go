@@ -0,0 +1,99 @@
+/*
+Copyright 2025 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// dockerHub is the registry of the images without a registry host
+	dockerHub = "docker.io"
+	// dockerHubAPI is the host serving the registry API of Docker Hub
+	dockerHubAPI = "registry-1.docker.io"
+	defaultTag   = "latest"
+)
+
+var digestPattern = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]+$`)
+
+// Reference is an image reference split in the parts the registry API is addressed with
+type Reference struct {
+	// Registry host, docker.io for the images without one
+	Registry string
+	// Repository in the registry, e.g. cloudservices/landing
+	Repository string
+	// Tag, latest for the images without a tag or digest
+	Tag string
+	// Digest the image is pinned to, if any
+	Digest string
+}
+
+// ParseReference splits an image reference such as quay.io/cloudservices/landing:abc
+func ParseReference(image string) (*Reference, error) {
+	ref := &Reference{}
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, ref.Digest = name[:i], name[i+1:]
+		if !digestPattern.MatchString(ref.Digest) {
+			return nil, fmt.Errorf("invalid digest in image %q", image)
+		}
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref.Tag = name[:i], name[i+1:]
+		if ref.Tag == "" {
+			return nil, fmt.Errorf("invalid tag in image %q", image)
+		}
+	}
+
+	// The first component is a registry host when it looks like one
+	ref.Registry, ref.Repository = dockerHub, name
+	if i := strings.Index(name, "/"); i >= 0 {
+		host := name[:i]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			ref.Registry, ref.Repository = host, name[i+1:]
+		}
+	}
+	if ref.Repository == "" || strings.ToLower(ref.Repository) != ref.Repository {
+		return nil, fmt.Errorf("invalid repository in image %q", image)
+	}
+	if ref.Registry == dockerHub && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = "library/" + ref.Repository
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = defaultTag
+	}
+	return ref, nil
+}
+
+// apiHost returns the host serving the registry API
+func (r *Reference) apiHost() string {
+	if r.Registry == dockerHub {
+		return dockerHubAPI
+	}
+	return r.Registry
+}
+
+// PinDigest returns the image pinned to the digest. The tag is kept for readability, the
+// container runtime pulls the digest.
+func PinDigest(image, digest string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	return image + "@" + digest
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2025 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package registry resolves the tags of the frontend images to digests through the
+// registry API, so a tag pushed again rolls the frontends out
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// requestTimeout bounds every request made to a registry
+const requestTimeout = 30 * time.Second
+
+// maxManifestSize bounds the manifests read to compute a digest the registry did not send
+const maxManifestSize = 4 << 20
+
+// manifestMediaTypes are accepted for the manifests, the digest of an index covers every
+// platform of the image
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// Resolver resolves image references to digests, tests replace it with a stand-in
+type Resolver interface {
+	Resolve(ctx context.Context, image string) (string, error)
+}
+
+// Config holds the credentials of the registries
+type Config struct {
+	// Data of a kubernetes.io/dockerconfigjson secret, if any
+	Credentials map[string][]byte
+}
+
+// NewResolver returns a Resolver for the registries implementing the distribution API
+func NewResolver(config Config) (Resolver, error) {
+	auths, err := parseDockerConfig(config.Credentials[".dockerconfigjson"])
+	if err != nil {
+		return nil, err
+	}
+	return &distributionResolver{
+		HTTPClient: &http.Client{Timeout: requestTimeout},
+		auths:      auths,
+	}, nil
+}
+
+// registryAuth is the username and password of a registry
+type registryAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Auth     string `json:"auth"`
+}
+
+// parseDockerConfig reads the registry credentials of a .dockerconfigjson
+func parseDockerConfig(data []byte) (map[string]registryAuth, error) {
+	auths := map[string]registryAuth{}
+	if len(data) == 0 {
+		return auths, nil
+	}
+	dockerConfig := struct {
+		Auths map[string]registryAuth `json:"auths"`
+	}{}
+	if err := json.Unmarshal(data, &dockerConfig); err != nil {
+		return nil, fmt.Errorf("invalid registry credentials: %w", err)
+	}
+	for host, auth := range dockerConfig.Auths {
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("invalid registry credentials of %s: %w", host, err)
+			}
+			auth.Username, auth.Password, _ = strings.Cut(string(decoded), ":")
+		}
+		// The hosts may be written as URLs, e.g. https://index.docker.io/v1/
+		if u, err := url.Parse(host); err == nil && u.Host != "" {
+			host = u.Host
+		}
+		if host == "index.docker.io" {
+			host = dockerHub
+		}
+		auths[host] = auth
+	}
+	return auths, nil
+}
+
+// distributionResolver reads the digest of the manifest of a tag from the registry
+type distributionResolver struct {
+	HTTPClient *http.Client
+
+	auths map[string]registryAuth
+}
+
+func (r *distributionResolver) Resolve(ctx context.Context, image string) (string, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return "", err
+	}
+	if ref.Digest != "" {
+		return ref.Digest, nil
+	}
+
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", ref.apiHost(), ref.Repository, ref.Tag)
+	resp, err := r.request(ctx, http.MethodHead, manifestURL, "")
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	// The registries asking for a token answer with a challenge first
+	authorization := ""
+	if resp.StatusCode == http.StatusUnauthorized {
+		authorization, err = r.authorize(ctx, ref, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return "", err
+		}
+		if resp, err = r.request(ctx, http.MethodHead, manifestURL, authorization); err != nil {
+			return "", err
+		}
+		resp.Body.Close()
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("resolving %s failed with %d", image, resp.StatusCode)
+	}
+	if digest := resp.Header.Get("Docker-Content-Digest"); digestPattern.MatchString(digest) {
+		return digest, nil
+	}
+
+	// Without the digest header the digest is computed from the manifest
+	if resp, err = r.request(ctx, http.MethodGet, manifestURL, authorization); err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("resolving %s failed with %d", image, resp.StatusCode)
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, io.LimitReader(resp.Body, maxManifestSize)); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (r *distributionResolver) request(ctx context.Context, method, url, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	return r.HTTPClient.Do(req)
+}
+
+// authorize answers the challenge of the registry, with a bearer token from the token service
+// of the registry or with the username and password
+func (r *distributionResolver) authorize(ctx context.Context, ref *Reference, challenge string) (string, error) {
+	auth, hasAuth := r.auths[ref.Registry]
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if !hasAuth {
+			return "", fmt.Errorf("%s requires credentials", ref.Registry)
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(auth.Username+":"+auth.Password)), nil
+	case "bearer":
+	default:
+		return "", fmt.Errorf("%s answered with an unsupported challenge %q", ref.Registry, challenge)
+	}
+
+	tokenURL, err := url.Parse(params["realm"])
+	if err != nil || tokenURL.Host == "" {
+		return "", fmt.Errorf("%s answered with an invalid token realm %q", ref.Registry, params["realm"])
+	}
+	query := tokenURL.Query()
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", ref.Repository))
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if hasAuth {
+		req.SetBasicAuth(auth.Username, auth.Password)
+	}
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request to %s failed with %d", tokenURL.Host, resp.StatusCode)
+	}
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&token); err != nil {
+		return "", fmt.Errorf("invalid token response of %s: %w", tokenURL.Host, err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return "", fmt.Errorf("token response of %s has no token", tokenURL.Host)
+	}
+	return "Bearer " + token.Token, nil
+}
+
+// parseChallenge splits a WWW-Authenticate header such as
+// Bearer realm="https://quay.io/v2/auth",service="quay.io"
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+	for rest != "" {
+		var param string
+		key, value, _ := strings.Cut(rest, "=")
+		key = strings.TrimSpace(key)
+		if strings.HasPrefix(value, `"`) {
+			value, rest, _ = strings.Cut(value[1:], `"`)
+			_, rest, _ = strings.Cut(rest, ",")
+		} else {
+			param, rest, _ = strings.Cut(value, ",")
+			value = strings.TrimSpace(param)
+		}
+		params[strings.ToLower(key)] = value
+	}
+	return scheme, params
+}
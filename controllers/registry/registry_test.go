@@ -0,0 +1,158 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseReference(t *testing.T) {
+	for _, test := range []struct {
+		image    string
+		expected Reference
+		err      string
+	}{
+		{image: "quay.io/cloudservices/landing:abc", expected: Reference{Registry: "quay.io", Repository: "cloudservices/landing", Tag: "abc"}},
+		{image: "localhost:5000/landing", expected: Reference{Registry: "localhost:5000", Repository: "landing", Tag: "latest"}},
+		{image: "caddy:2", expected: Reference{Registry: "docker.io", Repository: "library/caddy", Tag: "2"}},
+		{image: "redhat/ubi9", expected: Reference{Registry: "docker.io", Repository: "redhat/ubi9", Tag: "latest"}},
+		{image: "quay.io/landing:abc@sha256:0123abcd", expected: Reference{Registry: "quay.io", Repository: "landing", Tag: "abc", Digest: "sha256:0123abcd"}},
+		{image: "quay.io/landing@sha256:0123abcd", expected: Reference{Registry: "quay.io", Repository: "landing", Digest: "sha256:0123abcd"}},
+		{image: "quay.io/landing:", err: `invalid tag in image "quay.io/landing:"`},
+		{image: "quay.io/landing@latest", err: `invalid digest in image "quay.io/landing@latest"`},
+		{image: "quay.io/Landing", err: `invalid repository in image "quay.io/Landing"`},
+	} {
+		t.Run(test.image, func(t *testing.T) {
+			ref, err := ParseReference(test.image)
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					t.Errorf("expected %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *ref != test.expected {
+				t.Errorf("expected %+v, got %+v", test.expected, *ref)
+			}
+		})
+	}
+}
+
+func TestPinDigest(t *testing.T) {
+	if image := PinDigest("quay.io/landing:abc", "sha256:01"); image != "quay.io/landing:abc@sha256:01" {
+		t.Errorf("unexpected pinned image %s", image)
+	}
+	if image := PinDigest("quay.io/landing:abc@sha256:01", "sha256:02"); image != "quay.io/landing:abc@sha256:02" {
+		t.Errorf("unexpected pinned image %s", image)
+	}
+}
+
+// newTestRegistry serves a manifest behind a token service like quay.io does
+func newTestRegistry(t *testing.T, manifest string, sendDigest bool) (*httptest.Server, *distributionResolver) {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/auth":
+			username, password, ok := r.BasicAuth()
+			if !ok || username != "robot" || password != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if r.URL.Query().Get("scope") != "repository:cloudservices/landing:pull" || r.URL.Query().Get("service") != "registry.test" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"token":"pull-token"}`)
+		case "/v2/cloudservices/landing/manifests/abc":
+			if r.Header.Get("Authorization") != "Bearer pull-token" {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/v2/auth",service="registry.test"`, server.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if !strings.Contains(r.Header.Get("Accept"), "application/vnd.oci.image.index.v1+json") {
+				w.WriteHeader(http.StatusNotAcceptable)
+				return
+			}
+			if sendDigest {
+				w.Header().Set("Docker-Content-Digest", "sha256:fromheader")
+			}
+			if r.Method == http.MethodGet {
+				fmt.Fprint(w, manifest)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	host := strings.TrimPrefix(server.URL, "https://")
+	auth := base64.StdEncoding.EncodeToString([]byte("robot:secret"))
+	resolver, err := NewResolver(Config{Credentials: map[string][]byte{
+		".dockerconfigjson": []byte(fmt.Sprintf(`{"auths":{"%s":{"auth":"%s"}}}`, host, auth)),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := resolver.(*distributionResolver)
+	r.HTTPClient = server.Client()
+	return server, r
+}
+
+func TestResolve(t *testing.T) {
+	server, resolver := newTestRegistry(t, `{"schemaVersion":2}`, true)
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	digest, err := resolver.Resolve(context.Background(), host+"/cloudservices/landing:abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest != "sha256:fromheader" {
+		t.Errorf("expected the digest of the registry, got %s", digest)
+	}
+
+	_, err = resolver.Resolve(context.Background(), host+"/cloudservices/landing:def")
+	if err == nil || !strings.Contains(err.Error(), "failed with 404") {
+		t.Errorf("expected the missing tag to be reported, got %v", err)
+	}
+
+	// Pinned images are not resolved
+	if digest, err = resolver.Resolve(context.Background(), "quay.io/landing@sha256:01"); err != nil || digest != "sha256:01" {
+		t.Errorf("expected the pinned digest, got %s, %v", digest, err)
+	}
+
+	resolver.auths = map[string]registryAuth{}
+	_, err = resolver.Resolve(context.Background(), host+"/cloudservices/landing:abc")
+	if err == nil || !strings.Contains(err.Error(), "failed with 401") {
+		t.Errorf("expected the token request to fail without credentials, got %v", err)
+	}
+}
+
+func TestResolveWithoutDigestHeader(t *testing.T) {
+	manifest := `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.index.v1+json"}`
+	server, resolver := newTestRegistry(t, manifest, false)
+
+	digest, err := resolver.Resolve(context.Background(), strings.TrimPrefix(server.URL, "https://")+"/cloudservices/landing:abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte(manifest))
+	if expected := "sha256:" + hex.EncodeToString(sum[:]); digest != expected {
+		t.Errorf("expected the digest of the manifest %s, got %s", expected, digest)
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/caddy:pull"`)
+	if scheme != "Bearer" || params["realm"] != "https://auth.docker.io/token" || params["service"] != "registry.docker.io" || params["scope"] != "repository:library/caddy:pull" {
+		t.Errorf("unexpected challenge %s %v", scheme, params)
+	}
+}
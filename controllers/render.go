@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+)
+
+// RenderedConfig is the data of the config maps the operator generates for a FrontendEnvironment
+type RenderedConfig struct {
+	// Data of the <env> config map and its feo-context-cfg copies
+	Config map[string]string
+	// Data of the <env>-widget-registry config map and its widget-registry-cfg copies
+	WidgetRegistry map[string]string
+	// Data of the <env>-base-widget-dashboard-templates config map and its copies
+	BaseWidgetDashboardTemplates map[string]string
+
+	skippedTiles          []skippedConfigItem
+	skippedBundleSegments []skippedConfigItem
+	navRefIssues          []skippedConfigItem
+	skippedCaddyfiles     []skippedConfigItem
+}
+
+// Warnings lists the Frontend config that was left out of the rendered config
+func (c *RenderedConfig) Warnings() []string {
+	warnings := []string{}
+	for _, items := range [][]skippedConfigItem{c.skippedTiles, c.skippedBundleSegments, c.navRefIssues, c.skippedCaddyfiles} {
+		for _, item := range items {
+			warnings = append(warnings, fmt.Sprintf("%s/%s: %s", item.FrontendNamespace, item.FrontendName, item.String()))
+		}
+	}
+	return warnings
+}
+
+// Files merges the data of all rendered config maps, the keys do not overlap
+func (c *RenderedConfig) Files() map[string]string {
+	files := map[string]string{}
+	for _, data := range []map[string]string{c.Config, c.WidgetRegistry, c.BaseWidgetDashboardTemplates} {
+		for k, v := range data {
+			files[k] = v
+		}
+	}
+	return files
+}
+
+// RenderConfig runs the config generation of the operator for the Frontends of an environment
+// without touching the cluster, the result matches the data of the generated config maps.
+func RenderConfig(feEnv *crd.FrontendEnvironment, feList *crd.FrontendList) (*RenderedConfig, error) {
+	rendered := &RenderedConfig{}
+
+	var err error
+	if rendered.Config, err = renderConfigData(feEnv, feList, rendered); err != nil {
+		return nil, err
+	}
+	if rendered.WidgetRegistry, err = renderWidgetRegistryData(feList); err != nil {
+		return nil, err
+	}
+	if rendered.BaseWidgetDashboardTemplates, err = renderBaseWidgetDashboardTemplatesData(feList); err != nil {
+		return nil, err
+	}
+	return rendered, nil
+}
+
+func renderConfigData(feEnv *crd.FrontendEnvironment, feList *crd.FrontendList, rendered *RenderedConfig) (map[string]string, error) {
+	data := map[string]string{}
+
+	fedModules := make(map[string]crd.FedModule)
+	if err := setupFedModules(feEnv, feList, fedModules); err != nil {
+		return data, fmt.Errorf("error setting up fedModules: %w", err)
+	}
+
+	searchIndex := setupSearchIndex(feList)
+
+	serviceCategories, skippedTiles := setupServiceTilesData(feList, *feEnv)
+
+	bundles, skippedBundles, navRefIssues, err := setupBundlesData(feList, *feEnv)
+	if err != nil {
+		return data, err
+	}
+
+	rendered.skippedTiles = skippedTiles
+	rendered.skippedBundleSegments = skippedBundles
+	rendered.navRefIssues = navRefIssues
+
+	// Collect API specs from all frontends
+	apiSpecs := setupAPISpecs(feList)
+
+	fedModulesJSONData, err := json.Marshal(fedModules)
+	if err != nil {
+		return data, err
+	}
+
+	searchIndexJSONData, err := json.Marshal(searchIndex)
+	if err != nil {
+		return data, err
+	}
+
+	serviceCategoriesJSONData, err := json.Marshal(serviceCategories)
+	if err != nil {
+		return data, err
+	}
+
+	bundlesJSONData, err := json.Marshal(bundles)
+	if err != nil {
+		return data, err
+	}
+
+	apiSpecsJSONData, err := json.Marshal(apiSpecs)
+	if err != nil {
+		return data, err
+	}
+
+	data["fed-modules.json"] = string(fedModulesJSONData)
+	data["Caddyfile"] = defaultCaddyfile
+	for i := range feList.Items {
+		frontend := &feList.Items[i]
+		if !usesFrontendCaddyfile(frontend, feEnv) {
+			continue
+		}
+		caddyfile, err := renderFrontendCaddyfile(frontend)
+		if errors.Is(err, errUnbalancedCaddyBraces) {
+			// Only this frontend loses its Caddyfile, the others of the environment are kept
+			rendered.skippedCaddyfiles = append(rendered.skippedCaddyfiles, skippedConfigItem{
+				FrontendName:      frontend.Name,
+				FrontendNamespace: frontend.Namespace,
+				Kind:              "Caddyfile",
+				ID:                getCaddyfileKey(frontend, feEnv),
+				Reason:            err.Error(),
+			})
+			continue
+		}
+		if err != nil {
+			return data, err
+		}
+		data[getCaddyfileKey(frontend, feEnv)] = caddyfile
+	}
+	if len(searchIndex) > 0 {
+		data["search-index.json"] = string(searchIndexJSONData)
+	}
+
+	if len(serviceCategories) > 0 {
+		data["service-tiles.json"] = string(serviceCategoriesJSONData)
+	}
+
+	if len(bundles) > 0 {
+		data["bundles.json"] = string(bundlesJSONData)
+	}
+
+	if len(apiSpecs) > 0 {
+		data["api-specs.json"] = string(apiSpecsJSONData)
+	}
+
+	// Generate SSO configuration
+	ssoConfig := setupSSOConfig(feEnv)
+	ssoConfigJSONData, err := json.Marshal(ssoConfig)
+	if err != nil {
+		return data, err
+	}
+	data["sso-config.json"] = string(ssoConfigJSONData)
+
+	return data, nil
+}
+
+func renderWidgetRegistryData(feList *crd.FrontendList) (map[string]string, error) {
+	data := map[string]string{}
+
+	widgetRegistry := setupWidgetRegistry(feList)
+	widgetRegistryJSONData, err := json.Marshal(widgetRegistry)
+	if err != nil {
+		return data, err
+	}
+
+	if len(widgetRegistry) > 0 {
+		data["widget-registry.json"] = string(widgetRegistryJSONData)
+	}
+	return data, nil
+}
+
+func renderBaseWidgetDashboardTemplatesData(feList *crd.FrontendList) (map[string]string, error) {
+	data := map[string]string{}
+
+	baseWidgetDashboardTemplates := setupBaseWidgetDashboardTemplates(feList)
+	baseWidgetDashboardTemplatesJSONData, err := json.Marshal(baseWidgetDashboardTemplates)
+	if err != nil {
+		return data, err
+	}
+
+	if len(baseWidgetDashboardTemplates) > 0 {
+		data["base-widget-dashboard-templates.json"] = string(baseWidgetDashboardTemplatesJSONData)
+	}
+	return data, nil
+}
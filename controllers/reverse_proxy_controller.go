@@ -18,18 +18,26 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
 	"github.com/go-logr/logr"
 )
 
+// reverseProxyStatusRequeue is how often the ReverseProxyReady condition is
+// refreshed while the reverse proxy deployment is not yet available.
+const reverseProxyStatusRequeue = 30 * time.Second
+
 // ReverseProxyController reconciles reverse proxy resources based on FrontendEnvironment
 type ReverseProxyController struct {
 	client.Client
@@ -39,9 +47,14 @@ type ReverseProxyController struct {
 }
 
 //+kubebuilder:rbac:groups=cloud.redhat.com,resources=frontendenvironments,verbs=get;list;watch
+//+kubebuilder:rbac:groups=cloud.redhat.com,resources=frontendenvironments/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=route.openshift.io,resources=routes/custom-host,verbs=create
 
 // Reconcile handles the reverse proxy reconciliation for a FrontendEnvironment
 func (r *ReverseProxyController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -59,8 +72,11 @@ func (r *ReverseProxyController) Reconcile(ctx context.Context, req ctrl.Request
 	}
 
 	// Only deploy reverse proxy if push cache is enabled and reverse proxy image is configured
-	if !fe.Spec.EnablePushCache || fe.Spec.ReverseProxyImage == "" {
+	if !reverseProxyEnabled(fe) {
 		log.Info("Skipping reverse proxy reconciliation: push cache not enabled or no image configured")
+		if err := SetFrontendEnvironmentStatus(ctx, r.Client, fe.Name); err != nil {
+			return ctrl.Result{Requeue: true}, err
+		}
 		return ctrl.Result{}, nil
 	}
 
@@ -86,13 +102,24 @@ func (r *ReverseProxyController) Reconcile(ctx context.Context, req ctrl.Request
 	}
 
 	log.Info("Successfully reconciled reverse proxy", "namespace", namespace)
+
+	if err := SetFrontendEnvironmentStatus(ctx, r.Client, fe.Name); err != nil {
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	// The deployment is not watched, poll until it becomes available so the
+	// ReverseProxyReady condition catches up.
+	updated := &crd.FrontendEnvironment{}
+	if err := r.Client.Get(ctx, req.NamespacedName, updated); err == nil && !meta.IsStatusConditionTrue(updated.Status.Conditions, crd.ReverseProxyReady) {
+		return ctrl.Result{RequeueAfter: reverseProxyStatusRequeue}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ReverseProxyController) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&crd.FrontendEnvironment{}).
+		For(&crd.FrontendEnvironment{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Named("reverseproxy").
 		Complete(r)
 }
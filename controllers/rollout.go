@@ -0,0 +1,470 @@
+/*
+Copyright 2025 RedHatInsights.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	routev1 "github.com/openshift/api/route/v1"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+)
+
+const (
+	// canaryLabel selects the canary pods, they do not carry the frontend label the
+	// Deployment and the Service of the Frontend select their pods with
+	canaryLabel  = "frontend-canary"
+	canarySuffix = "-canary"
+
+	defaultCanaryWeight           = 10
+	defaultCanaryReplicas         = 1
+	defaultCanarySoakDuration     = 5 * time.Minute
+	defaultCanaryProgressDeadline = 10 * time.Minute
+
+	// rolloutPollInterval is how often the canary is checked, the Deployment status changes
+	// do not trigger a reconciliation
+	rolloutPollInterval = 15 * time.Second
+
+	defaultRolloutMessageLimit = 1024
+)
+
+// canaryRolloutEnabled returns true when a new image of the Frontend is rolled out through a canary
+func canaryRolloutEnabled(frontend *crd.Frontend) bool {
+	return frontend.Spec.Rollout != nil && frontend.Spec.Rollout.Strategy == crd.RolloutStrategyCanary
+}
+
+func getCanaryWeight(frontend *crd.Frontend) int32 {
+	if frontend.Spec.Rollout.CanaryWeight != nil {
+		return *frontend.Spec.Rollout.CanaryWeight
+	}
+	return defaultCanaryWeight
+}
+
+func getCanaryReplicas(frontend *crd.Frontend) *int32 {
+	if frontend.Spec.Rollout.CanaryReplicas != nil {
+		return frontend.Spec.Rollout.CanaryReplicas
+	}
+	return utils.Int32Ptr(defaultCanaryReplicas)
+}
+
+func getCanarySoakDuration(frontend *crd.Frontend) time.Duration {
+	if frontend.Spec.Rollout.SoakDuration != nil {
+		return frontend.Spec.Rollout.SoakDuration.Duration
+	}
+	return defaultCanarySoakDuration
+}
+
+func getCanaryProgressDeadline(frontend *crd.Frontend) time.Duration {
+	if frontend.Spec.Rollout.ProgressDeadline != nil {
+		return frontend.Spec.Rollout.ProgressDeadline.Duration
+	}
+	return defaultCanaryProgressDeadline
+}
+
+// getCanaryLabels returns the labels of the canary pods, the frontend label is swapped for
+// the canary label so the Deployment and the Service of the Frontend do not select them
+func getCanaryLabels(frontend *crd.Frontend) map[string]string {
+	labels := frontend.GetLabels()
+	delete(labels, "frontend")
+	labels[canaryLabel] = frontend.Name
+	return labels
+}
+
+// getRolloutStatus returns the canary rollout of the Frontend, nil unless its strategy is Canary
+func (r *FrontendReconciliation) getRolloutStatus() *crd.RolloutStatus {
+	if !canaryRolloutEnabled(r.Frontend) {
+		return nil
+	}
+	return r.Frontend.Status.Rollout
+}
+
+// getStableImage returns the image the Deployment of the Frontend runs. While a canary rolls
+// out it keeps the image of the last promoted canary, so the cache bust only purges the CDN
+// once the canary is promoted.
+func (r *FrontendReconciliation) getStableImage() string {
+	if status := r.getRolloutStatus(); status != nil && status.StableImage != "" {
+		return status.StableImage
+	}
+	return r.getFrontendImage()
+}
+
+// getPushCacheImage returns the image the push cache publishes: the canary image, so its
+// assets are published before it is promoted, and the stable image again once the canary is
+// rolled back
+func (r *FrontendReconciliation) getPushCacheImage() string {
+	if status := r.getRolloutStatus(); status != nil && status.Phase == crd.RolloutRolledBack {
+		return status.StableImage
+	}
+	return r.getFrontendImage()
+}
+
+// canaryActive returns true while the canary Deployment runs and serves requests
+func (r *FrontendReconciliation) canaryActive() bool {
+	status := r.getRolloutStatus()
+	return r.Frontend.Spec.Image != "" && status != nil && (status.Phase == crd.RolloutProgressing || status.Phase == crd.RolloutSoaking)
+}
+
+// getDeployedImage returns the image of the existing Deployment of the Frontend, empty when
+// it was not created yet
+func (r *FrontendReconciliation) getDeployedImage() (string, error) {
+	d := &apps.Deployment{}
+	nn := types.NamespacedName{Name: r.Frontend.Name + "-frontend", Namespace: r.Frontend.Namespace}
+	if err := r.Client.Get(r.Ctx, nn, d); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	if len(d.Spec.Template.Spec.Containers) == 0 {
+		return "", nil
+	}
+	return d.Spec.Template.Spec.Containers[0].Image, nil
+}
+
+// getCanaryPodFailure returns why the canary pods of the image failed their probes: a
+// container restarted after failing its liveness probe or crashing, or a pod of the ready
+// canary failing its readiness probe. Pods started after the canary became ready are only
+// checked for restarts, they have to become ready first.
+func getCanaryPodFailure(pods []v1.Pod, image string, readyTime *metav1.Time) string {
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || len(pod.Spec.Containers) == 0 || pod.Spec.Containers[0].Image != image {
+			continue
+		}
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.RestartCount == 0 {
+				continue
+			}
+			failure := fmt.Sprintf("Container %s of pod %s restarted %d times", containerStatus.Name, pod.Name, containerStatus.RestartCount)
+			if terminated := containerStatus.LastTerminationState.Terminated; terminated != nil {
+				failure += fmt.Sprintf(", last terminated with %s (exit code %d)", terminated.Reason, terminated.ExitCode)
+			}
+			return failure
+		}
+		if readyTime == nil || pod.Status.StartTime == nil || readyTime.Before(pod.Status.StartTime) {
+			continue
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == v1.PodReady && condition.Status == v1.ConditionFalse {
+				return fmt.Sprintf("Pod %s failed its readiness probe", pod.Name)
+			}
+		}
+	}
+	return ""
+}
+
+// getCanaryFailure returns why the canary has to be rolled back, failed probes or assets the
+// push cache failed to publish
+func (r *FrontendReconciliation) getCanaryFailure(status *crd.RolloutStatus) (string, error) {
+	if r.FrontendEnvironment.Spec.EnablePushCache && r.Frontend.Status.PushCache != nil {
+		if run := r.Frontend.Status.PushCache.LastRun; run != nil && run.Image == status.CanaryImage && run.Phase == crd.PushCacheFailed {
+			return fmt.Sprintf("Publishing the assets of %s failed: %s", run.Image, run.Message), nil
+		}
+	}
+
+	pods := &v1.PodList{}
+	if err := r.Client.List(r.Ctx, pods, client.InNamespace(r.Frontend.Namespace), client.MatchingLabels{canaryLabel: r.Frontend.Name}); err != nil {
+		return "", err
+	}
+	return getCanaryPodFailure(pods.Items, status.CanaryImage, status.ReadyTime), nil
+}
+
+// ImageRolledOutCondition builds the ImageRolledOut condition from the canary rollout of a
+// Frontend, the image is rolled out once the Deployment runs it
+func ImageRolledOutCondition(image string, status *crd.RolloutStatus) metav1.Condition {
+	condition := metav1.Condition{
+		Type:    crd.ImageRolledOut,
+		Status:  metav1.ConditionFalse,
+		Reason:  "CanaryProgressing",
+		Message: fmt.Sprintf("Waiting for the canary of %s to become ready", status.CanaryImage),
+	}
+	switch {
+	case status.StableImage == image:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "RolledOut"
+		condition.Message = fmt.Sprintf("The Deployment runs %s", image)
+	case status.Phase == crd.RolloutSoaking:
+		condition.Reason = "CanarySoaking"
+		condition.Message = status.Message
+	case status.Phase == crd.RolloutRolledBack:
+		condition.Reason = "CanaryRolledBack"
+		condition.Message = fmt.Sprintf("The canary of %s was rolled back: %s", status.CanaryImage, status.Message)
+	}
+	return condition
+}
+
+// SetFrontendRolloutStatus replaces the canary rollout status of the Frontend and the
+// ImageRolledOut condition, a nil status clears both
+func SetFrontendRolloutStatus(ctx context.Context, pClient client.Client, nn types.NamespacedName, status *crd.RolloutStatus, image string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		o := &crd.Frontend{}
+		if err := pClient.Get(ctx, nn, o); err != nil {
+			return err
+		}
+
+		oldStatus := o.Status.DeepCopy()
+		o.Status.Rollout = status
+		if status == nil {
+			meta.RemoveStatusCondition(&o.Status.Conditions, crd.ImageRolledOut)
+		} else {
+			meta.SetStatusCondition(&o.Status.Conditions, ImageRolledOutCondition(image, status))
+		}
+		if equality.Semantic.DeepEqual(*oldStatus, o.Status) {
+			return nil
+		}
+		return pClient.Status().Update(ctx, o)
+	})
+}
+
+// reconcileRollout moves the canary rollout of the Frontend image along. A new image is
+// served by the canary Deployment to a share of the requests until it is ready, has soaked
+// and the push cache published its assets, then the Deployment of the Frontend is updated to
+// it. A canary failing its probes, not ready within the progress deadline or whose assets
+// failed to publish is rolled back.
+func (r *FrontendReconciliation) reconcileRollout() error {
+	image := r.getFrontendImage()
+	status := r.Frontend.Status.Rollout.DeepCopy()
+	if status == nil {
+		// An existing Deployment keeps its image until the canary of the new one is promoted,
+		// the first image is rolled out right away
+		deployedImage, err := r.getDeployedImage()
+		if err != nil {
+			return err
+		}
+		if deployedImage == "" {
+			deployedImage = image
+		}
+		status = &crd.RolloutStatus{StableImage: deployedImage, Phase: crd.RolloutPromoted}
+	}
+	now := metav1.Now()
+
+	switch {
+	case status.StableImage == image:
+		if status.Phase == crd.RolloutProgressing || status.Phase == crd.RolloutSoaking {
+			status.Phase = crd.RolloutRolledBack
+			status.CompletionTime = &now
+			status.Message = "The image was changed back to the stable image"
+			r.recordEvent(v1.EventTypeNormal, "CanaryAbandoned", "Abandoned the canary of %s, the image was changed back to %s", status.CanaryImage, image)
+		}
+		return r.setRolloutStatus(status)
+	case status.CanaryImage != image:
+		// A new image, it replaces a canary still rolling out
+		status.CanaryImage = image
+		status.Phase = crd.RolloutProgressing
+		status.StartTime = &now
+		status.ReadyTime = nil
+		status.CompletionTime = nil
+		status.Message = ""
+		r.recordEvent(v1.EventTypeNormal, "CanaryStarted", "Rolling %s out to %d%% of the requests", image, getCanaryWeight(r.Frontend))
+		r.requeueAfter(rolloutPollInterval)
+		return r.setRolloutStatus(status)
+	case status.Phase == crd.RolloutRolledBack:
+		// The canary is only rolled out again for a new image
+		return r.setRolloutStatus(status)
+	}
+
+	r.requeueAfter(rolloutPollInterval)
+	failure, err := r.getCanaryFailure(status)
+	if err != nil {
+		return err
+	}
+	if failure != "" {
+		return r.rollBack(status, failure)
+	}
+
+	if status.Phase == crd.RolloutProgressing {
+		d := &apps.Deployment{}
+		nn := types.NamespacedName{Name: r.Frontend.Name + "-frontend" + canarySuffix, Namespace: r.Frontend.Namespace}
+		if err := r.Client.Get(r.Ctx, nn, d); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		if !deploymentRolledOut(d, image) {
+			if deadline := getCanaryProgressDeadline(r.Frontend); time.Since(status.StartTime.Time) > deadline {
+				return r.rollBack(status, fmt.Sprintf("The canary did not become ready within %s", deadline))
+			}
+			return r.setRolloutStatus(status)
+		}
+		status.Phase = crd.RolloutSoaking
+		status.ReadyTime = &now
+	}
+
+	if soakEnd := status.ReadyTime.Add(getCanarySoakDuration(r.Frontend)); now.Before(&metav1.Time{Time: soakEnd}) {
+		status.Message = fmt.Sprintf("Serving %d%% of the requests until %s", getCanaryWeight(r.Frontend), soakEnd.UTC().Format(time.RFC3339))
+		return r.setRolloutStatus(status)
+	}
+	if r.FrontendEnvironment.Spec.EnablePushCache {
+		if pushCache := r.Frontend.Status.PushCache; pushCache == nil || pushCache.LastRun == nil ||
+			pushCache.LastRun.Image != image || pushCache.LastRun.Phase != crd.PushCacheSucceeded {
+			status.Message = fmt.Sprintf("Waiting for the push cache to publish the assets of %s", image)
+			return r.setRolloutStatus(status)
+		}
+	}
+
+	status.StableImage = image
+	status.Phase = crd.RolloutPromoted
+	status.CompletionTime = &now
+	status.Message = ""
+	r.recordEvent(v1.EventTypeNormal, "CanaryPromoted", "Promoted the canary of %s after %s", image, now.Sub(status.StartTime.Time).Round(time.Second))
+	rolloutsMetric.WithLabelValues(r.Frontend.Name, "promoted").Inc()
+	return r.setRolloutStatus(status)
+}
+
+// rollBack removes the canary, the Deployment keeps the stable image
+func (r *FrontendReconciliation) rollBack(status *crd.RolloutStatus, reason string) error {
+	now := metav1.Now()
+	status.Phase = crd.RolloutRolledBack
+	status.CompletionTime = &now
+	status.Message = truncateMessage(reason, defaultRolloutMessageLimit)
+	r.recordEvent(v1.EventTypeWarning, "CanaryRolledBack", "Rolled the canary of %s back: %s", status.CanaryImage, status.Message)
+	rolloutsMetric.WithLabelValues(r.Frontend.Name, "rolled_back").Inc()
+	return r.setRolloutStatus(status)
+}
+
+// setRolloutStatus persists the status right away, the Deployment is built from it and a
+// promotion must not be repeated because a later step of the reconciliation failed
+func (r *FrontendReconciliation) setRolloutStatus(status *crd.RolloutStatus) error {
+	nn := types.NamespacedName{Name: r.Frontend.Name, Namespace: r.Frontend.Namespace}
+	if err := SetFrontendRolloutStatus(r.Ctx, r.Client, nn, status, r.getFrontendImage()); err != nil {
+		return err
+	}
+	r.Frontend.Status.Rollout = status
+	return nil
+}
+
+// createCanaryDeployment runs the canary image next to the Deployment of the Frontend
+func (r *FrontendReconciliation) createCanaryDeployment(annotationHashes []map[string]string) error {
+	d := &apps.Deployment{}
+	nn := types.NamespacedName{
+		Name:      r.Frontend.Name + "-frontend" + canarySuffix,
+		Namespace: r.Frontend.Namespace,
+	}
+	if err := r.Cache.Create(CanaryDeployment, nn, d); err != nil {
+		return err
+	}
+
+	labeler := utils.GetCustomLabeler(r.Frontend.GetLabels(), nn, r.Frontend)
+	labeler(d)
+
+	d.Spec.Replicas = getCanaryReplicas(r.Frontend)
+	if err := r.populateDeployment(d, r.Frontend.Status.Rollout.CanaryImage, getCanaryLabels(r.Frontend), annotationHashes); err != nil {
+		return err
+	}
+
+	// The canary Service has its own serving certificate
+	for i := range d.Spec.Template.Spec.Volumes {
+		if secret := d.Spec.Template.Spec.Volumes[i].Secret; secret != nil && d.Spec.Template.Spec.Volumes[i].Name == "certs" {
+			secret.SecretName = fmt.Sprintf("%s%s-cert", r.Frontend.Name, canarySuffix)
+		}
+	}
+
+	return r.Cache.Update(CanaryDeployment, d)
+}
+
+// createCanaryService selects the canary pods, the routing splits the requests between it and
+// the Service of the Frontend
+func (r *FrontendReconciliation) createCanaryService() error {
+	s := &v1.Service{}
+	nn := types.NamespacedName{
+		Name:      r.Frontend.Name + canarySuffix,
+		Namespace: r.Frontend.Namespace,
+	}
+	if err := r.Cache.Create(CanaryService, nn, s); err != nil {
+		return err
+	}
+
+	if r.FrontendEnvironment.Spec.SSL {
+		annotations := s.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations["service.beta.openshift.io/serving-cert-secret-name"] = fmt.Sprintf("%s-%s", nn.Name, "cert")
+		s.SetAnnotations(annotations)
+	}
+
+	s.SetOwnerReferences([]metav1.OwnerReference{r.Frontend.MakeOwnerReference()})
+	utils.MakeService(s, nn, map[string]string{canaryLabel: r.Frontend.Name}, createPorts(), r.Frontend, false)
+
+	return r.Cache.Update(CanaryService, s)
+}
+
+// createCanaryIngress sends the canary weight of the requests to the canary Service with the
+// canary annotations of ingress-nginx
+func (r *FrontendReconciliation) createCanaryIngress() error {
+	netobj := &networking.Ingress{}
+	nn := types.NamespacedName{
+		Name:      r.Frontend.Name + canarySuffix,
+		Namespace: r.Frontend.Namespace,
+	}
+	if err := r.Cache.Create(WebIngress, nn, netobj); err != nil {
+		return err
+	}
+
+	labeler := utils.GetCustomLabeler(r.Frontend.GetLabels(), nn, r.Frontend)
+	labeler(netobj)
+	netobj.SetOwnerReferences([]metav1.OwnerReference{r.Frontend.MakeOwnerReference()})
+
+	r.createAnnotationsAndPopulate(nn, netobj)
+
+	annotations := netobj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations["nginx.ingress.kubernetes.io/canary"] = "true"
+	annotations["nginx.ingress.kubernetes.io/canary-weight"] = strconv.Itoa(int(getCanaryWeight(r.Frontend)))
+	netobj.SetAnnotations(annotations)
+
+	return r.Cache.Update(WebIngress, netobj)
+}
+
+// addCanaryBackendRef splits the requests of the HTTPRoute rules between the Service of the
+// Frontend and the canary Service
+func (r *FrontendReconciliation) addCanaryBackendRef(spec *gatewayv1.HTTPRouteSpec) {
+	weight := int(getCanaryWeight(r.Frontend))
+	for i := range spec.Rules {
+		rule := &spec.Rules[i]
+		if len(rule.BackendRefs) != 1 {
+			continue
+		}
+		canary := *rule.BackendRefs[0].DeepCopy()
+		canary.Name = gatewayv1.ObjectName(r.Frontend.Name + canarySuffix)
+		canary.Weight = utils.Int32Ptr(weight)
+		rule.BackendRefs[0].Weight = utils.Int32Ptr(100 - weight)
+		rule.BackendRefs = append(rule.BackendRefs, canary)
+	}
+}
+
+// addCanaryAlternateBackend splits the requests of the Route between the Service of the
+// Frontend and the canary Service
+func (r *FrontendReconciliation) addCanaryAlternateBackend(spec *routev1.RouteSpec) {
+	weight := int(getCanaryWeight(r.Frontend))
+	spec.To.Weight = utils.Int32Ptr(100 - weight)
+	spec.AlternateBackends = []routev1.RouteTargetReference{{
+		Kind:   "Service",
+		Name:   r.Frontend.Name + canarySuffix,
+		Weight: utils.Int32Ptr(weight),
+	}}
+}
@@ -0,0 +1,315 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+	routev1 "github.com/openshift/api/route/v1"
+	apps "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func canaryTestObjects() (*crd.Frontend, *crd.FrontendEnvironment) {
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	frontend.Spec.Rollout = &crd.RolloutConfig{
+		Strategy:     crd.RolloutStrategyCanary,
+		CanaryWeight: utils.Int32Ptr(20),
+		SoakDuration: &metav1.Duration{Duration: time.Minute},
+	}
+	return frontend, frontendEnvironment
+}
+
+// markDeploymentRolledOut sets the status the Deployment controller reports once every
+// replica runs the image
+func markDeploymentRolledOut(t *testing.T, pClient client.Client, name string) {
+	t.Helper()
+	d := &apps.Deployment{}
+	if err := pClient.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "boot"}, d); err != nil {
+		t.Fatal(err)
+	}
+	d.Status.ObservedGeneration = d.Generation
+	d.Status.Replicas = *d.Spec.Replicas
+	d.Status.UpdatedReplicas = *d.Spec.Replicas
+	d.Status.AvailableReplicas = *d.Spec.Replicas
+	if err := pClient.Status().Update(context.Background(), d); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func getStoredRollout(t *testing.T, pClient client.Client) (*crd.RolloutStatus, *metav1.Condition) {
+	t.Helper()
+	stored := &crd.Frontend{}
+	if err := pClient.Get(context.Background(), types.NamespacedName{Name: "landing", Namespace: "boot"}, stored); err != nil {
+		t.Fatal(err)
+	}
+	return stored.Status.Rollout, meta.FindStatusCondition(stored.Status.Conditions, crd.ImageRolledOut)
+}
+
+func TestCanaryRollout(t *testing.T) {
+	setPushCacheEnv(t)
+	ctx := context.Background()
+	frontend, frontendEnvironment := canaryTestObjects()
+	frontendEnvironment.Spec.EnablePushCache = true
+	frontendEnvironment.Spec.ValpopImage = "quay.io/valpop:1"
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend).WithStatusSubresource(&crd.Frontend{}).Build()
+	getDeployment := func(name string) (*apps.Deployment, error) {
+		d := &apps.Deployment{}
+		return d, pClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "boot"}, d)
+	}
+	getJob := func() *batchv1.Job {
+		t.Helper()
+		job := &batchv1.Job{}
+		if err := pClient.Get(ctx, types.NamespacedName{Name: "landing-frontend-pushcache", Namespace: "boot"}, job); err != nil {
+			t.Fatal(err)
+		}
+		return job
+	}
+
+	// The first image is rolled out right away
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	status, condition := getStoredRollout(t, pClient)
+	if status == nil || status.Phase != crd.RolloutPromoted || status.StableImage != "quay.io/landing:abc" {
+		t.Fatalf("expected the first image to be promoted, got %+v", status)
+	}
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		t.Errorf("expected the image to be rolled out, got %v", condition)
+	}
+
+	// A new image is served by the canary
+	frontend.Spec.Image = "quay.io/landing:def"
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	status, condition = getStoredRollout(t, pClient)
+	if status.Phase != crd.RolloutProgressing || status.CanaryImage != "quay.io/landing:def" || status.StartTime == nil {
+		t.Fatalf("expected the canary to progress, got %+v", status)
+	}
+	if condition.Status != metav1.ConditionFalse || condition.Reason != "CanaryProgressing" {
+		t.Errorf("expected the image not to be rolled out, got %v", condition)
+	}
+	d, err := getDeployment("landing-frontend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if image := d.Spec.Template.Spec.Containers[0].Image; image != "quay.io/landing:abc" {
+		t.Errorf("expected the Deployment to keep the stable image, got %s", image)
+	}
+	canary, err := getDeployment("landing-frontend-canary")
+	if err != nil {
+		t.Fatalf("expected the canary Deployment to be created: %v", err)
+	}
+	if image := canary.Spec.Template.Spec.Containers[0].Image; image != "quay.io/landing:def" {
+		t.Errorf("expected the canary to run the new image, got %s", image)
+	}
+	if *canary.Spec.Replicas != 1 {
+		t.Errorf("expected a single canary replica, got %d", *canary.Spec.Replicas)
+	}
+	podLabels := canary.Spec.Template.Labels
+	if podLabels["frontend-canary"] != "landing" || podLabels["frontend"] != "" {
+		t.Errorf("expected the canary pods to be kept out of the Service of the Frontend, got %v", podLabels)
+	}
+	service := &v1.Service{}
+	if err := pClient.Get(ctx, types.NamespacedName{Name: "landing-canary", Namespace: "boot"}, service); err != nil {
+		t.Fatalf("expected the canary Service to be created: %v", err)
+	}
+	if service.Spec.Selector["frontend-canary"] != "landing" || service.Spec.Selector["frontend"] != "" {
+		t.Errorf("expected the canary Service to select the canary pods, got %v", service.Spec.Selector)
+	}
+	ingress := &networking.Ingress{}
+	if err := pClient.Get(ctx, types.NamespacedName{Name: "landing-canary", Namespace: "boot"}, ingress); err != nil {
+		t.Fatalf("expected the canary Ingress to be created: %v", err)
+	}
+	if ingress.Annotations["nginx.ingress.kubernetes.io/canary"] != "true" || ingress.Annotations["nginx.ingress.kubernetes.io/canary-weight"] != "20" {
+		t.Errorf("expected the canary annotations, got %v", ingress.Annotations)
+	}
+	if backend := ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name; backend != "landing-canary" {
+		t.Errorf("expected the canary Ingress to route to the canary Service, got %s", backend)
+	}
+	if image := getJob().Spec.Template.Annotations["frontend-image"]; image != "quay.io/landing:def" {
+		t.Errorf("expected the push cache to publish the canary image, got %s", image)
+	}
+
+	// The ready canary soaks
+	markDeploymentRolledOut(t, pClient, "landing-frontend-canary")
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	status, condition = getStoredRollout(t, pClient)
+	if status.Phase != crd.RolloutSoaking || status.ReadyTime == nil || !strings.HasPrefix(status.Message, "Serving 20% of the requests") {
+		t.Fatalf("expected the canary to soak, got %+v", status)
+	}
+	if condition.Reason != "CanarySoaking" {
+		t.Errorf("expected the soak to be reported, got %v", condition)
+	}
+
+	// The canary is only promoted once the push cache published its assets
+	readyTime := metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	frontend.Status.Rollout.ReadyTime = &readyTime
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	status, _ = getStoredRollout(t, pClient)
+	if status.Phase != crd.RolloutSoaking || status.Message != "Waiting for the push cache to publish the assets of quay.io/landing:def" {
+		t.Fatalf("expected the promotion to wait for the push cache, got %+v", status)
+	}
+
+	job := getJob()
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}}
+	if err := pClient.Status().Update(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	status, condition = getStoredRollout(t, pClient)
+	if status.Phase != crd.RolloutPromoted || status.StableImage != "quay.io/landing:def" || status.CompletionTime == nil {
+		t.Fatalf("expected the canary to be promoted, got %+v", status)
+	}
+	if condition.Status != metav1.ConditionTrue {
+		t.Errorf("expected the image to be rolled out, got %v", condition)
+	}
+	if d, _ = getDeployment("landing-frontend"); d.Spec.Template.Spec.Containers[0].Image != "quay.io/landing:def" {
+		t.Errorf("expected the Deployment to run the promoted image, got %s", d.Spec.Template.Spec.Containers[0].Image)
+	}
+	if _, err := getDeployment("landing-frontend-canary"); !k8serr.IsNotFound(err) {
+		t.Errorf("expected the canary Deployment to be removed, got %v", err)
+	}
+	if err := pClient.Get(ctx, types.NamespacedName{Name: "landing-canary", Namespace: "boot"}, &v1.Service{}); !k8serr.IsNotFound(err) {
+		t.Errorf("expected the canary Service to be removed, got %v", err)
+	}
+	if err := pClient.Get(ctx, types.NamespacedName{Name: "landing-canary", Namespace: "boot"}, &networking.Ingress{}); !k8serr.IsNotFound(err) {
+		t.Errorf("expected the canary Ingress to be removed, got %v", err)
+	}
+}
+
+func TestCanaryRollback(t *testing.T) {
+	setPushCacheEnv(t)
+	ctx := context.Background()
+	frontend, frontendEnvironment := canaryTestObjects()
+	frontendEnvironment.Spec.EnablePushCache = true
+	frontendEnvironment.Spec.ValpopImage = "quay.io/valpop:1"
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "landing-frontend-canary-1", Namespace: "boot", Labels: map[string]string{"frontend-canary": "landing"}},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "fe-image", Image: "quay.io/landing:def"}}},
+		Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{
+			Name:                 "fe-image",
+			RestartCount:         2,
+			LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}},
+		}}},
+	}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend, pod).WithStatusSubresource(&crd.Frontend{}).Build()
+
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	frontend.Spec.Image = "quay.io/landing:def"
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	status, condition := getStoredRollout(t, pClient)
+	if status.Phase != crd.RolloutRolledBack || status.StableImage != "quay.io/landing:abc" {
+		t.Fatalf("expected the canary to be rolled back, got %+v", status)
+	}
+	if status.Message != "Container fe-image of pod landing-frontend-canary-1 restarted 2 times, last terminated with Error (exit code 1)" {
+		t.Errorf("unexpected rollback reason %q", status.Message)
+	}
+	if condition.Reason != "CanaryRolledBack" {
+		t.Errorf("expected the rollback to be reported, got %v", condition)
+	}
+	if err := pClient.Get(ctx, types.NamespacedName{Name: "landing-frontend-canary", Namespace: "boot"}, &apps.Deployment{}); !k8serr.IsNotFound(err) {
+		t.Errorf("expected the canary Deployment to be removed, got %v", err)
+	}
+
+	// The push cache publishes the stable image again
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	job := &batchv1.Job{}
+	if err := pClient.Get(ctx, types.NamespacedName{Name: "landing-frontend-pushcache", Namespace: "boot"}, job); err != nil {
+		t.Fatal(err)
+	}
+	if image := job.Spec.Template.Annotations["frontend-image"]; image != "quay.io/landing:abc" {
+		t.Errorf("expected the push cache to publish the stable image, got %s", image)
+	}
+
+	// A canary that does not become ready within the progress deadline is rolled back
+	if err := pClient.Delete(ctx, pod); err != nil {
+		t.Fatal(err)
+	}
+	frontend.Spec.Image = "quay.io/landing:ghi"
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	startTime := metav1.NewTime(time.Now().Add(-11 * time.Minute))
+	frontend.Status.Rollout.StartTime = &startTime
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	status, _ = getStoredRollout(t, pClient)
+	if status.Phase != crd.RolloutRolledBack || status.Message != "The canary did not become ready within 10m0s" {
+		t.Errorf("expected the canary to be rolled back after the progress deadline, got %+v", status)
+	}
+}
+
+func TestCanaryRouting(t *testing.T) {
+	ctx := context.Background()
+	frontend, frontendEnvironment := canaryTestObjects()
+	frontend.Spec.Rollout.CanaryWeight = nil
+	frontendEnvironment.Spec.RoutingMode = crd.RoutingModeGatewayAPI
+	frontendEnvironment.Spec.Gateway = &crd.GatewayParentRef{Name: "public", Namespace: "gateways", SectionName: "https"}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend).WithStatusSubresource(&crd.Frontend{}).Build()
+	nn := types.NamespacedName{Name: "landing", Namespace: "boot"}
+
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	frontend.Spec.Image = "quay.io/landing:def"
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	route := &gatewayv1.HTTPRoute{}
+	if err := pClient.Get(ctx, nn, route); err != nil {
+		t.Fatal(err)
+	}
+	backendRefs := route.Spec.Rules[0].BackendRefs
+	if len(backendRefs) != 2 || *backendRefs[0].Weight != 90 || backendRefs[1].Name != "landing-canary" || *backendRefs[1].Weight != 10 {
+		t.Errorf("expected the default canary weight to be split off, got %+v", backendRefs)
+	}
+
+	frontendEnvironment.Spec.RoutingMode = crd.RoutingModeRoute
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+	routes := &routev1.RouteList{}
+	if err := pClient.List(ctx, routes, client.InNamespace("boot")); err != nil {
+		t.Fatal(err)
+	}
+	if len(routes.Items) == 0 {
+		t.Fatal("expected the routes to be created")
+	}
+	for _, r := range routes.Items {
+		if *r.Spec.To.Weight != 90 || len(r.Spec.AlternateBackends) != 1 || r.Spec.AlternateBackends[0].Name != "landing-canary" || *r.Spec.AlternateBackends[0].Weight != 10 {
+			t.Errorf("expected route %s to send the canary weight to the canary Service, got %+v", r.Name, r.Spec)
+		}
+	}
+}
+
+func TestGetCanaryPodFailure(t *testing.T) {
+	readyTime := metav1.NewTime(time.Now())
+	before := metav1.NewTime(readyTime.Add(-time.Minute))
+	after := metav1.NewTime(readyTime.Add(time.Minute))
+	notReady := []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionFalse}}
+	pods := []v1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "previous"},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Image: "quay.io/landing:abc"}}},
+			Status:     v1.PodStatus{StartTime: &before, Conditions: notReady},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "starting"},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Image: "quay.io/landing:def"}}},
+			Status:     v1.PodStatus{StartTime: &after, Conditions: notReady},
+		},
+	}
+	if failure := getCanaryPodFailure(pods, "quay.io/landing:def", &readyTime); failure != "" {
+		t.Errorf("expected pods of other images and pods still starting to be ignored, got %q", failure)
+	}
+	if failure := getCanaryPodFailure(pods, "quay.io/landing:abc", nil); failure != "" {
+		t.Errorf("expected readiness to be ignored before the canary is ready, got %q", failure)
+	}
+	if failure := getCanaryPodFailure(pods, "quay.io/landing:abc", &readyTime); failure != "Pod previous failed its readiness probe" {
+		t.Errorf("expected the readiness probe failure, got %q", failure)
+	}
+}
@@ -0,0 +1,231 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
+	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
+	v1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testServingCert = "-----BEGIN CERTIFICATE-----\nc2VydmluZw==\n-----END CERTIFICATE-----\n"
+	testServiceCA   = "-----BEGIN CERTIFICATE-----\nc2VydmljZS1jYQ==\n-----END CERTIFICATE-----\n"
+)
+
+func TestFrontendRoutes(t *testing.T) {
+	frontend, frontendEnvironment := autoscalingTestObjects()
+	frontend.Spec.Hosts = []string{"console.example.com", "console.example.org"}
+	frontendEnvironment.Spec.SSL = true
+	frontendEnvironment.Spec.RoutingMode = crd.RoutingModeRoute
+	frontendEnvironment.Spec.Whitelist = []string{"10.0.0.0/8", "192.168.0.0/16"}
+	servingCert := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "landing-cert", Namespace: "boot"},
+		Data:       map[string][]byte{"tls.crt": []byte(testServingCert + testServiceCA)},
+	}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(frontend, frontendEnvironment, servingCert).Build()
+
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	for i, host := range frontend.Spec.Hosts {
+		route := &routev1.Route{}
+		nn := types.NamespacedName{Name: fmt.Sprintf("landing-%d", i), Namespace: "boot"}
+		if err := pClient.Get(context.Background(), nn, route); err != nil {
+			t.Fatalf("expected route %s to be created: %v", nn, err)
+		}
+		if route.Spec.Host != host || route.Spec.Path != "/apps/landing" {
+			t.Errorf("expected %s/apps/landing, got %s%s", host, route.Spec.Host, route.Spec.Path)
+		}
+		if route.Spec.To.Name != "landing" || route.Spec.Port.TargetPort.StrVal != "public" {
+			t.Errorf("expected the public port of the frontend service, got %v %v", route.Spec.To, route.Spec.Port)
+		}
+		tls := route.Spec.TLS
+		if tls.Termination != routev1.TLSTerminationReencrypt || tls.InsecureEdgeTerminationPolicy != routev1.InsecureEdgeTerminationPolicyRedirect {
+			t.Errorf("expected reencrypt with a redirect, got %v", tls)
+		}
+		if tls.DestinationCACertificate != testServiceCA {
+			t.Errorf("expected the service CA as destination CA, got %q", tls.DestinationCACertificate)
+		}
+		if route.Annotations["haproxy.router.openshift.io/ip_whitelist"] != "10.0.0.0/8 192.168.0.0/16" {
+			t.Errorf("expected the whitelist annotation, got %v", route.Annotations)
+		}
+		if len(route.OwnerReferences) != 1 || route.OwnerReferences[0].Name != "landing" {
+			t.Errorf("expected the route to be owned by the frontend, got %v", route.OwnerReferences)
+		}
+	}
+	if err := pClient.Get(context.Background(), types.NamespacedName{Name: "landing", Namespace: "boot"}, &networking.Ingress{}); !k8serr.IsNotFound(err) {
+		t.Errorf("expected no ingress in Route mode, got %v", err)
+	}
+
+	// Passthrough routes can not match paths, so a single route per host is kept
+	frontendEnvironment.Spec.RouteTLS = &crd.RouteTLSConfig{Termination: "passthrough", InsecureEdgeTerminationPolicy: "None"}
+	frontend.Spec.Hosts = nil
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	routes := &routev1.RouteList{}
+	if err := pClient.List(context.Background(), routes); err != nil {
+		t.Fatal(err)
+	}
+	if len(routes.Items) != 1 {
+		t.Fatalf("expected the route of the second host to be removed, got %d routes", len(routes.Items))
+	}
+	route := routes.Items[0]
+	if route.Spec.Host != "stage.example.com" || route.Spec.Path != "" || route.Spec.TLS.Termination != routev1.TLSTerminationPassthrough {
+		t.Errorf("expected a passthrough route for the environment hostname, got %v", route.Spec)
+	}
+
+	frontendEnvironment.Spec.RoutingMode = crd.RoutingModeIngress
+	frontendEnvironment.Spec.Whitelist = nil
+	reconcileFrontendResources(t, pClient, frontend, frontendEnvironment)
+
+	if err := pClient.List(context.Background(), routes); err != nil {
+		t.Fatal(err)
+	}
+	if len(routes.Items) != 0 {
+		t.Errorf("expected the routes to be removed, got %d", len(routes.Items))
+	}
+	if err := pClient.Get(context.Background(), types.NamespacedName{Name: "landing", Namespace: "boot"}, &networking.Ingress{}); err != nil {
+		t.Errorf("expected the ingress to be restored: %v", err)
+	}
+}
+
+func TestGetRouteTLSConfig(t *testing.T) {
+	frontendEnvironment := &crd.FrontendEnvironment{}
+	if tls := getRouteTLSConfig(frontendEnvironment); tls.Termination != routev1.TLSTerminationEdge {
+		t.Errorf("expected edge termination without ssl, got %v", tls.Termination)
+	}
+
+	frontendEnvironment.Spec.SSL = true
+	frontendEnvironment.Spec.RouteTLS = &crd.RouteTLSConfig{InsecureEdgeTerminationPolicy: "Allow"}
+	tls := getRouteTLSConfig(frontendEnvironment)
+	if tls.Termination != routev1.TLSTerminationReencrypt || tls.InsecureEdgeTerminationPolicy != routev1.InsecureEdgeTerminationPolicyAllow {
+		t.Errorf("expected reencrypt allowing plain http, got %v", tls)
+	}
+}
+
+func TestGetServingCA(t *testing.T) {
+	ctx := context.Background()
+	nn := types.NamespacedName{Name: "landing-cert", Namespace: "boot"}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	if ca, err := getServingCA(ctx, pClient, nn); err != nil || ca != "" {
+		t.Errorf("expected no CA before the certificate is issued, got %q %v", ca, err)
+	}
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: nn.Name, Namespace: nn.Namespace},
+		Data:       map[string][]byte{"tls.crt": []byte(testServingCert)},
+	}
+	if err := pClient.Create(ctx, secret); err != nil {
+		t.Fatal(err)
+	}
+	if ca, err := getServingCA(ctx, pClient, nn); err != nil || ca != "" {
+		t.Errorf("expected the leaf certificate not to be used as CA, got %q %v", ca, err)
+	}
+
+	secret.Data["ca.crt"] = []byte(testServiceCA)
+	if err := pClient.Update(ctx, secret); err != nil {
+		t.Fatal(err)
+	}
+	if ca, err := getServingCA(ctx, pClient, nn); err != nil || ca != testServiceCA {
+		t.Errorf("expected the ca.crt of the secret, got %q %v", ca, err)
+	}
+}
+
+func TestGetFrontendRouteStatus(t *testing.T) {
+	frontend, _ := autoscalingTestObjects()
+	route := func(name string, conditions ...routev1.RouteIngressCondition) *routev1.Route {
+		return &routev1.Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "boot",
+				Labels:          map[string]string{"frontend": "landing"},
+				OwnerReferences: []metav1.OwnerReference{frontend.MakeOwnerReference()},
+			},
+			Status: routev1.RouteStatus{Ingress: []routev1.RouteIngress{{
+				Host:       "stage.example.com",
+				RouterName: "default",
+				Conditions: conditions,
+			}}},
+		}
+	}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		route("landing-0", routev1.RouteIngressCondition{Type: routev1.RouteAdmitted, Status: v1.ConditionTrue}),
+		route("landing-1", routev1.RouteIngressCondition{Type: routev1.RouteAdmitted, Status: v1.ConditionFalse, Reason: "HostAlreadyClaimed", Message: "route landing-0 already exposes stage.example.com"}),
+	).Build()
+
+	statuses, err := GetFrontendRouteStatus(context.Background(), pClient, frontend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("expected two route statuses, got %v", statuses)
+	}
+	if !statuses[0].Admitted || statuses[0].Host != "stage.example.com" {
+		t.Errorf("expected the first route to be admitted, got %v", statuses[0])
+	}
+	if statuses[1].Admitted || statuses[1].Message != "HostAlreadyClaimed: route landing-0 already exposes stage.example.com" {
+		t.Errorf("expected the rejection reason on the second route, got %v", statuses[1])
+	}
+}
+
+func TestReverseProxyRoute(t *testing.T) {
+	frontendEnvironment := &crd.FrontendEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "stage", UID: "stage-uid"},
+		Spec: crd.FrontendEnvironmentSpec{
+			ReverseProxyHostname: "proxy.example.com",
+			RoutingMode:          crd.RoutingModeRoute,
+		},
+	}
+	pClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciliation := &ReverseProxyReconciliation{
+		Log:                 logr.Discard(),
+		Client:              pClient,
+		Ctx:                 context.Background(),
+		Namespace:           "stage",
+		FrontendEnvironment: frontendEnvironment,
+	}
+	nn := types.NamespacedName{Name: "reverse-proxy", Namespace: "stage"}
+
+	if err := reconciliation.reconcileRoute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	route := &routev1.Route{}
+	if err := pClient.Get(context.Background(), nn, route); err != nil {
+		t.Fatalf("expected the Route to be created: %v", err)
+	}
+	if route.Spec.Host != "proxy.example.com" || route.Spec.To.Name != "reverse-proxy" || route.Spec.Port.TargetPort.StrVal != "http" {
+		t.Errorf("expected the reverse proxy route, got %v", route.Spec)
+	}
+	if route.Spec.TLS.Termination != routev1.TLSTerminationEdge {
+		t.Errorf("expected edge termination without ssl, got %v", route.Spec.TLS)
+	}
+
+	// An unchanged route is not updated again
+	resourceVersion := route.ResourceVersion
+	if err := reconciliation.reconcileRoute(); err != nil {
+		t.Fatal(err)
+	}
+	if err := pClient.Get(context.Background(), nn, route); err != nil {
+		t.Fatal(err)
+	}
+	if route.ResourceVersion != resourceVersion {
+		t.Errorf("expected the unchanged route to be left alone")
+	}
+
+	if err := deleteOwnedRoutes(context.Background(), pClient, "stage", reconciliation.getReverseProxyLabels(), frontendEnvironment.UID); err != nil {
+		t.Fatal(err)
+	}
+	if err := pClient.Get(context.Background(), nn, &routev1.Route{}); !k8serr.IsNotFound(err) {
+		t.Errorf("expected the Route to be removed, got %v", err)
+	}
+}
@@ -2,13 +2,18 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"math"
+	"sort"
+	"strings"
 
 	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/errors"
 	crd "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
 	"github.com/RedHatInsights/rhc-osdk-utils/resources"
 	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -16,7 +21,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func SetFrontendConditions(ctx context.Context, client client.Client, o *crd.Frontend, state string, conditionErr error) error {
+func SetFrontendConditions(ctx context.Context, client client.Client, o *crd.Frontend, state string, conditionErr error, extraConditions ...metav1.Condition) error {
 	// RetryOnConflict handles 409 conflicts caused by the Frontend CR's
 	// resourceVersion changing between the reconciler's initial Get and this
 	// status update (e.g. annotation changes, concurrent reconcilers).
@@ -72,6 +77,7 @@ func SetFrontendConditions(ctx context.Context, client client.Client, o *crd.Fro
 		}
 
 		conditions = append(conditions, *condition)
+		conditions = append(conditions, extraConditions...)
 		for _, condition := range conditions {
 			innerCondition := condition
 			meta.SetStatusCondition(&o.Status.Conditions, innerCondition)
@@ -86,6 +92,12 @@ func SetFrontendConditions(ctx context.Context, client client.Client, o *crd.Fro
 		o.Status.Deployments.ManagedDeployments = stats.ManagedDeployments
 		o.Status.Deployments.ReadyDeployments = stats.ReadyDeployments
 
+		routes, err := GetFrontendRouteStatus(ctx, client, o)
+		if err != nil {
+			return err
+		}
+		o.Status.Routes = routes
+
 		if !equality.Semantic.DeepEqual(*oldStatus, o.Status) {
 			if err := client.Status().Update(ctx, o); err != nil {
 				return err
@@ -95,6 +107,118 @@ func SetFrontendConditions(ctx context.Context, client client.Client, o *crd.Fro
 	})
 }
 
+// ConfigurationWarningsCondition builds the ConfigurationWarnings condition from the
+// parts of a Frontend's config that were left out of the generated config.
+func ConfigurationWarningsCondition(warnings []string) metav1.Condition {
+	condition := metav1.Condition{
+		Type:    crd.ConfigurationWarnings,
+		Status:  metav1.ConditionFalse,
+		Reason:  "NoWarnings",
+		Message: "All configuration was included in the generated config",
+	}
+	if len(warnings) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "ConfigurationSkipped"
+		condition.Message = fmt.Sprintf("Skipped configuration: %s", strings.Join(warnings, "; "))
+	}
+	return condition
+}
+
+// NavigationReferencesCondition builds the NavigationReferencesResolved condition from the
+// dangling or cyclic segment references made by a Frontend.
+func NavigationReferencesCondition(issues []string) metav1.Condition {
+	condition := metav1.Condition{
+		Type:    crd.NavigationReferencesResolved,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ReferencesResolved",
+		Message: "All navigation segment references were resolved",
+	}
+	if len(issues) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "UnresolvedReferences"
+		condition.Message = fmt.Sprintf("Unresolved navigation segment references: %s", strings.Join(issues, "; "))
+	}
+	return condition
+}
+
+// SetFrontendCondition sets conditions on a Frontend without touching the rest of its status.
+func SetFrontendCondition(ctx context.Context, pClient client.Client, nn types.NamespacedName, conditions ...metav1.Condition) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		o := &crd.Frontend{}
+		if err := pClient.Get(ctx, nn, o); err != nil {
+			return err
+		}
+
+		changed := false
+		for _, condition := range conditions {
+			if meta.SetStatusCondition(&o.Status.Conditions, condition) {
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+		return pClient.Status().Update(ctx, o)
+	})
+}
+
+// SetFrontendCacheBustStatus replaces the cache bust status of the Frontend, a nil status
+// clears it
+func SetFrontendCacheBustStatus(ctx context.Context, pClient client.Client, nn types.NamespacedName, status *crd.CacheBustStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		o := &crd.Frontend{}
+		if err := pClient.Get(ctx, nn, o); err != nil {
+			return err
+		}
+
+		if equality.Semantic.DeepEqual(o.Status.CacheBust, status) {
+			return nil
+		}
+		o.Status.CacheBust = status
+		return pClient.Status().Update(ctx, o)
+	})
+}
+
+// SetFrontendPushCacheStatus replaces the push cache status of the Frontend and the
+// AssetsPublished condition reporting whether its last run published the image, a nil status
+// clears both
+func SetFrontendPushCacheStatus(ctx context.Context, pClient client.Client, nn types.NamespacedName, status *crd.PushCacheStatus, image string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		o := &crd.Frontend{}
+		if err := pClient.Get(ctx, nn, o); err != nil {
+			return err
+		}
+
+		oldStatus := o.Status.DeepCopy()
+		o.Status.PushCache = status
+		if status == nil || status.LastRun == nil {
+			meta.RemoveStatusCondition(&o.Status.Conditions, crd.AssetsPublished)
+		} else {
+			meta.SetStatusCondition(&o.Status.Conditions, AssetsPublishedCondition(image, status.LastRun))
+		}
+		if equality.Semantic.DeepEqual(*oldStatus, o.Status) {
+			return nil
+		}
+		return pClient.Status().Update(ctx, o)
+	})
+}
+
+// SetFrontendImageStatus replaces the resolved image in the status of the Frontend, a nil
+// status clears it
+func SetFrontendImageStatus(ctx context.Context, pClient client.Client, nn types.NamespacedName, status *crd.ImageStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		o := &crd.Frontend{}
+		if err := pClient.Get(ctx, nn, o); err != nil {
+			return err
+		}
+		if equality.Semantic.DeepEqual(o.Status.Image, status) {
+			return nil
+		}
+		o.Status.Image = status
+		return pClient.Status().Update(ctx, o)
+	})
+}
+
 func GetFrontendResources(ctx context.Context, client client.Client, o *crd.Frontend) (bool, error) {
 	stats, _, err := GetFrontendFigures(ctx, client, o)
 	if err == nil {
@@ -138,3 +262,183 @@ func GetFrontendFigures(ctx context.Context, client client.Client, o *crd.Fronte
 
 	return deploymentStats, results.BrokenMessage, nil
 }
+
+// SetFrontendEnvironmentStatus recomputes the aggregated status of the named FrontendEnvironment
+// from its member Frontends, the generated config maps and the reverse proxy deployment.
+func SetFrontendEnvironmentStatus(ctx context.Context, pClient client.Client, envName string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		fe := &crd.FrontendEnvironment{}
+		if err := pClient.Get(ctx, types.NamespacedName{Name: envName}, fe); err != nil {
+			return err
+		}
+
+		feList, err := fe.GetFrontendsInEnv(ctx, pClient)
+		if err != nil {
+			return errors.Wrap("list frontends: ", err)
+		}
+
+		configMapList := &v1.ConfigMapList{}
+		if err := pClient.List(ctx, configMapList, client.MatchingLabels{"frontendenv": fe.Name}); err != nil {
+			return errors.Wrap("list config maps: ", err)
+		}
+
+		var reverseProxy *apps.Deployment
+		if reverseProxyEnabled(fe) {
+			reverseProxy = &apps.Deployment{}
+			err := pClient.Get(ctx, types.NamespacedName{Name: "reverse-proxy", Namespace: fe.Name}, reverseProxy)
+			if k8serr.IsNotFound(err) {
+				reverseProxy = nil
+			} else if err != nil {
+				return errors.Wrap("get reverse proxy: ", err)
+			}
+		}
+
+		oldStatus := fe.Status.DeepCopy()
+		populateFrontendEnvironmentStatus(fe, feList.Items, configMapList.Items, reverseProxy)
+
+		if !equality.Semantic.DeepEqual(*oldStatus, fe.Status) {
+			if err := pClient.Status().Update(ctx, fe); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func reverseProxyEnabled(fe *crd.FrontendEnvironment) bool {
+	return fe.Spec.EnablePushCache && fe.Spec.ReverseProxyImage != ""
+}
+
+// isGeneratedConfigMap tells the generated config maps apart from the other
+// config maps that carry the environment labels (SSO config, Caddy config etc).
+func isGeneratedConfigMap(envName string, name string) bool {
+	switch name {
+	case envName,
+		envName + WidgetRegistryConfigMapSuffix,
+		envName + BaseWidgetDashboardTemplatesConfigMapSuffix,
+		FrontendContextConfigMapName,
+		FrontendContextConfigMapName + "-beta",
+		WidgetRegistryContextConfigMapName,
+		BaseWidgetDashboardTemplatesContextName:
+		return true
+	}
+	return false
+}
+
+func populateFrontendEnvironmentStatus(fe *crd.FrontendEnvironment, frontends []crd.Frontend, configMaps []v1.ConfigMap, reverseProxy *apps.Deployment) {
+	status := &fe.Status
+	status.Frontends = 0
+	status.ReadyFrontends = 0
+	status.FeoConfigEnabledFrontends = nil
+	status.ConfigMaps = nil
+
+	frontendNamespaces := map[string]bool{}
+	activeFrontends := []crd.Frontend{}
+	for _, frontend := range frontends {
+		if frontend.GetDeletionTimestamp() != nil {
+			continue
+		}
+		activeFrontends = append(activeFrontends, frontend)
+		status.Frontends++
+		if frontend.Status.Ready {
+			status.ReadyFrontends++
+		}
+		if frontend.Spec.FeoConfigEnabled {
+			status.FeoConfigEnabledFrontends = append(status.FeoConfigEnabledFrontends, fmt.Sprintf("%s/%s", frontend.Namespace, frontend.Name))
+		}
+		frontendNamespaces[frontend.Namespace] = true
+	}
+	sort.Strings(status.FeoConfigEnabledFrontends)
+
+	generated := map[types.NamespacedName]bool{}
+	for _, cm := range configMaps {
+		if !isGeneratedConfigMap(fe.Name, cm.Name) {
+			continue
+		}
+		hash, _ := createConfigmapHash([]map[string]string{cm.Data})
+		status.ConfigMaps = append(status.ConfigMaps, crd.GeneratedConfigMap{
+			Name:      cm.Name,
+			Namespace: cm.Namespace,
+			Hash:      hash,
+		})
+		generated[types.NamespacedName{Name: cm.Name, Namespace: cm.Namespace}] = true
+	}
+	sort.Slice(status.ConfigMaps, func(i, j int) bool {
+		if status.ConfigMaps[i].Namespace != status.ConfigMaps[j].Namespace {
+			return status.ConfigMaps[i].Namespace < status.ConfigMaps[j].Namespace
+		}
+		return status.ConfigMaps[i].Name < status.ConfigMaps[j].Name
+	})
+
+	// The same config maps the environment config reconciliation creates are expected
+	missing := []string{}
+	expectGenerated := func(namespace string, names ...string) {
+		for _, name := range names {
+			if !generated[types.NamespacedName{Name: name, Namespace: namespace}] {
+				missing = append(missing, fmt.Sprintf("%s/%s", namespace, name))
+			}
+		}
+	}
+	for namespace := range frontendNamespaces {
+		expectGenerated(namespace, fe.Name, fe.Name+WidgetRegistryConfigMapSuffix, fe.Name+BaseWidgetDashboardTemplatesConfigMapSuffix)
+	}
+	if status.Frontends > 0 {
+		contextNames := append(frontendContextConfigMapNames(activeFrontends), WidgetRegistryContextConfigMapName, BaseWidgetDashboardTemplatesContextName)
+		for _, namespace := range fe.Spec.TargetNamespaces {
+			expectGenerated(namespace, contextNames...)
+		}
+	}
+	sort.Strings(missing)
+
+	configCondition := metav1.Condition{
+		Type:    crd.ConfigGenerated,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ConfigMapsGenerated",
+		Message: fmt.Sprintf("%d generated config maps", len(status.ConfigMaps)),
+	}
+	switch {
+	case status.Frontends == 0:
+		configCondition.Status = metav1.ConditionFalse
+		configCondition.Reason = "NoFrontends"
+		configCondition.Message = "No frontends reference this environment"
+	case len(missing) > 0:
+		configCondition.Status = metav1.ConditionFalse
+		configCondition.Reason = "ConfigMapsMissing"
+		configCondition.Message = fmt.Sprintf("Missing generated config maps: %s", strings.Join(missing, ", "))
+	}
+	meta.SetStatusCondition(&status.Conditions, configCondition)
+
+	if fe.GatewayRoutingBlocked() {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    crd.RoutingModeApplied,
+			Status:  metav1.ConditionFalse,
+			Reason:  "WhitelistNotSupported",
+			Message: fmt.Sprintf("whitelist is not supported with routingMode %s, the frontends are kept on Ingress until it is removed", crd.RoutingModeGatewayAPI),
+		})
+	} else {
+		meta.RemoveStatusCondition(&status.Conditions, crd.RoutingModeApplied)
+	}
+
+	if !reverseProxyEnabled(fe) {
+		meta.RemoveStatusCondition(&status.Conditions, crd.ReverseProxyReady)
+		return
+	}
+
+	proxyCondition := metav1.Condition{
+		Type:    crd.ReverseProxyReady,
+		Status:  metav1.ConditionFalse,
+		Reason:  "DeploymentNotFound",
+		Message: "Reverse proxy deployment has not been created yet",
+	}
+	if reverseProxy != nil {
+		proxyCondition.Reason = "DeploymentNotAvailable"
+		proxyCondition.Message = fmt.Sprintf("%d/%d reverse proxy replicas ready", reverseProxy.Status.ReadyReplicas, reverseProxy.Status.Replicas)
+		for _, c := range reverseProxy.Status.Conditions {
+			if c.Type == apps.DeploymentAvailable && c.Status == v1.ConditionTrue {
+				proxyCondition.Status = metav1.ConditionTrue
+				proxyCondition.Reason = "DeploymentAvailable"
+			}
+		}
+	}
+	meta.SetStatusCondition(&status.Conditions, proxyCondition)
+}
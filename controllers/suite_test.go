@@ -91,6 +91,14 @@ var _ = ginkgo.BeforeSuite(func(_ context.Context) {
 	}).SetupWithManager(k8sManager)
 	gomega.Expect(err).ToNot(gomega.HaveOccurred())
 
+	err = (&EnvironmentConfigReconciler{
+		Client:   k8sManagerClient,
+		Scheme:   k8sManager.GetScheme(),
+		Log:      ctrl.Log.WithName("controllers").WithName("EnvironmentConfig"),
+		Debounce: 100 * time.Millisecond,
+	}).SetupWithManager(k8sManager)
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
 	ctx, cancel := context.WithCancel(context.Background())
 	stopController = cancel
 
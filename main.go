@@ -20,12 +20,14 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	routev1 "github.com/openshift/api/route/v1"
 	prom "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -33,6 +35,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	cloudredhatcomv1alpha1 "github.com/RedHatInsights/frontend-operator/api/v1alpha1"
 	"github.com/RedHatInsights/frontend-operator/controllers"
@@ -49,6 +52,8 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(prom.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1.Install(scheme))
+	utilruntime.Must(routev1.Install(scheme))
 
 	utilruntime.Must(cloudredhatcomv1alpha1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
@@ -63,12 +68,22 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var logLevel int
+	var enableWebhooks bool
+	var configAPIAddr string
+	var configDebounce time.Duration
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.IntVar(&logLevel, "log-level", 2, "Minimum log level (-1=Debug, 0=Info, 1=Warn, 2=Error)")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Enable the validating admission webhook for Frontend resources. "+
+			"Requires serving certificates in the webhook server cert directory.")
+	flag.StringVar(&configAPIAddr, "config-api-bind-address", "0",
+		"The address the read-only generated config API binds to. Set to 0 to disable the config API.")
+	flag.DurationVar(&configDebounce, "config-debounce", controllers.DefaultConfigDebounce,
+		"How long changes to the Frontends of an environment are collected before its config is generated.")
 	flag.Parse()
 
 	logger, err := logging.SetupLoggingWithLevel(true, int8(logLevel))
@@ -79,14 +94,14 @@ func main() {
 
 	ctrl.SetLogger(zapr.NewLogger(logger))
 
-	err = Run(metricsAddr, probeAddr, enableLeaderElection)
+	err = Run(metricsAddr, probeAddr, configAPIAddr, configDebounce, enableLeaderElection, enableWebhooks)
 	if err != nil {
 		_ = logger.Sync()
 		os.Exit(1)
 	}
 }
 
-func Run(metricsAddr, probeAddr string, enableLeaderElection bool) error {
+func Run(metricsAddr, probeAddr, configAPIAddr string, configDebounce time.Duration, enableLeaderElection, enableWebhooks bool) error {
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
 		Metrics: metricsserver.Options{
@@ -102,14 +117,26 @@ func Run(metricsAddr, probeAddr string, enableLeaderElection bool) error {
 	}
 
 	if err = (&controllers.FrontendReconciler{
-		Log:    ctrl.Log.WithName("controllers").WithName("Frontend"),
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Log:      ctrl.Log.WithName("controllers").WithName("Frontend"),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("frontend-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Frontend")
 		return fmt.Errorf("unable to create manager: %w", err)
 	}
 
+	if err = (&controllers.EnvironmentConfigReconciler{
+		Log:      ctrl.Log.WithName("controllers").WithName("EnvironmentConfig"),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("frontend-controller"),
+		Debounce: configDebounce,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EnvironmentConfig")
+		return fmt.Errorf("unable to create environment config controller: %w", err)
+	}
+
 	if err = (&controllers.ReverseProxyController{
 		Log:      ctrl.Log.WithName("controllers").WithName("ReverseProxy"),
 		Client:   mgr.GetClient(),
@@ -119,6 +146,23 @@ func Run(metricsAddr, probeAddr string, enableLeaderElection bool) error {
 		setupLog.Error(err, "unable to create controller", "controller", "ReverseProxy")
 		return fmt.Errorf("unable to create reverse proxy controller: %w", err)
 	}
+
+	if enableWebhooks {
+		if err = cloudredhatcomv1alpha1.SetupFrontendWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Frontend")
+			return fmt.Errorf("unable to create frontend webhook: %w", err)
+		}
+	}
+	if configAPIAddr != "0" {
+		if err = mgr.Add(&controllers.ConfigAPIServer{
+			Addr:   configAPIAddr,
+			Client: mgr.GetClient(),
+			Log:    ctrl.Log.WithName("config-api"),
+		}); err != nil {
+			setupLog.Error(err, "unable to create config API server")
+			return fmt.Errorf("unable to create config API server: %w", err)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {